@@ -0,0 +1,16 @@
+data "huggingface_endpoint_recommendation" "llm" {
+  repository = "mistralai/Mistral-7B-Instruct-v0.3"
+  image      = "vllm"
+}
+
+resource "huggingface_endpoint" "llm" {
+  name    = "mistral-7b"
+  type    = "protected"
+  compute = data.huggingface_endpoint_recommendation.llm.compute
+  model   = data.huggingface_endpoint_recommendation.llm.model
+
+  cloud = {
+    vendor = "aws"
+    region = "us-east-1"
+  }
+}
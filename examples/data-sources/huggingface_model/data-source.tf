@@ -0,0 +1,14 @@
+data "huggingface_model" "embedder" {
+  repository = "sentence-transformers/all-MiniLM-L6-v2"
+}
+
+resource "huggingface_endpoint" "embedder" {
+  # ...
+  model = {
+    repository = data.huggingface_model.embedder.repository
+    revision   = data.huggingface_model.embedder.sha
+    task       = data.huggingface_model.embedder.pipeline_tag
+    framework  = data.huggingface_model.embedder.framework
+    # ...
+  }
+}
@@ -0,0 +1,3 @@
+data "huggingface_organization" "team" {
+  name = "my-org"
+}
@@ -0,0 +1,4 @@
+data "huggingface_repo_access_requests" "pending" {
+  repo_id = huggingface_repository.classifier.id
+  status  = "pending"
+}
@@ -37,6 +37,8 @@ resource "huggingface_endpoint" "product_identification_reran_soy" {
   }
 
   type = "private"
+
+  hub_validation = "warn"
 }
 
 output "product_identification_reran_soy" {
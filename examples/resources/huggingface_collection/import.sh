@@ -0,0 +1 @@
+terraform import huggingface_collection.production my-org/production-models-6512f3a4b2c1d0e9f8a7b6c5
@@ -0,0 +1,17 @@
+resource "huggingface_collection" "production" {
+  title       = "Production models"
+  description = "Models currently served by our inference endpoints"
+  theme       = "green"
+
+  items = [
+    {
+      type = "model"
+      id   = huggingface_endpoint.classifier.model.repository
+      note = "Serves the product classifier"
+    },
+    {
+      type = "paper"
+      id   = "2310.06825"
+    },
+  ]
+}
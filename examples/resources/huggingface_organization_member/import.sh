@@ -0,0 +1 @@
+terraform import 'huggingface_organization_member.engineers["jdoe"]' my-org/jdoe
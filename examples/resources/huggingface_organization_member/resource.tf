@@ -0,0 +1,7 @@
+resource "huggingface_organization_member" "engineers" {
+  for_each = var.ml_engineers # map of username => role
+
+  organization = "my-org"
+  user         = each.key
+  role         = each.value
+}
@@ -0,0 +1 @@
+terraform import 'huggingface_repo_access_grant.partners["jdoe"]' my-org/product-classifier:jdoe
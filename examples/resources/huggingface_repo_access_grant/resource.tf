@@ -0,0 +1,11 @@
+data "huggingface_repo_access_requests" "pending" {
+  repo_id = huggingface_repository.classifier.id
+  status  = "pending"
+}
+
+resource "huggingface_repo_access_grant" "partners" {
+  for_each = toset(var.partner_users)
+
+  repo_id = huggingface_repository.classifier.id
+  user    = each.value
+}
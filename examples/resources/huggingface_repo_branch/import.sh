@@ -0,0 +1,3 @@
+# Models are imported by ID, other repositories by type and ID.
+terraform import huggingface_repo_branch.staging my-org/product-classifier:staging
+terraform import huggingface_repo_branch.data_staging dataset/my-org/product-data:staging
@@ -0,0 +1,5 @@
+resource "huggingface_repo_branch" "staging" {
+  repo_id  = huggingface_repository.classifier.id
+  name     = "staging"
+  revision = huggingface_repo_folder.weights.commit_sha
+}
@@ -0,0 +1,12 @@
+resource "huggingface_repo_file" "weights" {
+  repo_id        = huggingface_repository.classifier.id
+  path           = "model.safetensors"
+  source         = "${path.module}/build/model.safetensors"
+  commit_message = "Upload trained weights"
+}
+
+resource "huggingface_repo_file" "readme" {
+  repo_id = huggingface_repository.classifier.id
+  path    = "README.md"
+  content = file("${path.module}/MODEL_CARD.md")
+}
@@ -0,0 +1,14 @@
+resource "huggingface_repo_folder" "weights" {
+  repo_id    = huggingface_repository.classifier.id
+  source_dir = "${path.module}/build/model"
+  exclude    = ["*.log", "checkpoint-*"]
+}
+
+resource "huggingface_endpoint" "classifier" {
+  # ...
+  model = {
+    repository = huggingface_repository.classifier.id
+    revision   = huggingface_repo_folder.weights.commit_sha
+    # ...
+  }
+}
@@ -0,0 +1,3 @@
+# Models are imported by ID, other repositories by type and ID.
+terraform import huggingface_repo_tag.prod my-org/product-classifier:prod-2026-10
+terraform import huggingface_repo_tag.data_prod dataset/my-org/product-data:prod-2026-10
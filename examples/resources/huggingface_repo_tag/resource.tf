@@ -0,0 +1,15 @@
+resource "huggingface_repo_tag" "prod" {
+  repo_id  = huggingface_repository.classifier.id
+  name     = "prod-2026-10"
+  revision = huggingface_repo_folder.weights.commit_sha
+  message  = "Promote October retrain"
+}
+
+resource "huggingface_endpoint" "classifier" {
+  # ...
+  model = {
+    repository = huggingface_repository.classifier.id
+    revision   = huggingface_repo_tag.prod.name
+    # ...
+  }
+}
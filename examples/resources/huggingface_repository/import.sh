@@ -0,0 +1,3 @@
+# Models are imported by ID, other repositories by type and ID.
+terraform import huggingface_repository.classifier my-org/product-classifier
+terraform import huggingface_repository.data dataset/my-org/product-data
@@ -0,0 +1,5 @@
+resource "huggingface_repository" "classifier" {
+  name    = "product-classifier"
+  private = true
+  gated   = "manual"
+}
@@ -0,0 +1 @@
+terraform import huggingface_resource_group.research my-org/6540e2f1b2c3d4e5f6a7b8c9
@@ -0,0 +1,10 @@
+resource "huggingface_resource_group" "research" {
+  organization = "my-org"
+  name         = "research"
+  description  = "Models owned by the research team"
+
+  members = [
+    { user = "jdoe", role = "admin" },
+    { user = "asmith", role = "write" },
+  ]
+}
@@ -0,0 +1,4 @@
+resource "huggingface_resource_group_repository" "classifier" {
+  resource_group_id = huggingface_resource_group.research.id
+  repo_id           = huggingface_repository.classifier.id
+}
@@ -0,0 +1 @@
+terraform import huggingface_space.demo my-org/classifier-demo
@@ -0,0 +1,11 @@
+resource "huggingface_space" "demo" {
+  name             = "classifier-demo"
+  sdk              = "gradio"
+  hardware         = "cpu-upgrade"
+  sleep_time       = 3600
+  wait_for_running = true
+
+  timeouts = {
+    create = "30m"
+  }
+}
@@ -0,0 +1,5 @@
+resource "huggingface_space_secret" "token" {
+  space_id = huggingface_space.demo.id
+  key      = "HF_TOKEN"
+  value    = var.endpoint_token
+}
@@ -0,0 +1 @@
+terraform import huggingface_space_variable.endpoint_url my-org/classifier-demo/ENDPOINT_URL
@@ -0,0 +1,5 @@
+resource "huggingface_space_variable" "endpoint_url" {
+  space_id = huggingface_space.demo.id
+  key      = "ENDPOINT_URL"
+  value    = huggingface_endpoint.classifier.status.url
+}
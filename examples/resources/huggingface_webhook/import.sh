@@ -0,0 +1 @@
+terraform import huggingface_webhook.redeploy 6540e2f1b2c3d4e5f6a7b8c9
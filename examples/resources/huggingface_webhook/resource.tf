@@ -0,0 +1,10 @@
+resource "huggingface_webhook" "redeploy" {
+  url     = "https://ci.example.com/hooks/huggingface"
+  domains = ["repo"]
+  secret  = var.webhook_secret
+
+  watched = [
+    { type = "model", name = huggingface_repository.classifier.id },
+    { type = "org", name = "my-org" },
+  ]
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+// The inference endpoints catalog lists the instances each cloud vendor
+// offers in each of its regions. It is served next to the endpoint API, under
+// /v2/provider instead of /v2/endpoint/{namespace}, and returns
+//
+//	{"vendors": [{"name": "aws", "status": "available", "regions": [
+//	  {"name": "us-east-1", "status": "available", "computes": [...]}]}]}
+//
+// where each compute is a CatalogCompute.
+
+const catalogStatusAvailable = "available"
+
+type EndpointCatalog struct {
+	Vendors []CatalogVendor `json:"vendors"`
+}
+
+type CatalogVendor struct {
+	Name    string          `json:"name"`
+	Status  string          `json:"status"`
+	Regions []CatalogRegion `json:"regions"`
+}
+
+type CatalogRegion struct {
+	Name     string           `json:"name"`
+	Label    string           `json:"label"`
+	Status   string           `json:"status"`
+	Computes []CatalogCompute `json:"computes"`
+}
+
+// CatalogCompute is an instance type and size. MemoryGb is the RAM of the
+// instance and GpuMemoryGb the accelerator memory of all its accelerators
+// together.
+type CatalogCompute struct {
+	Accelerator     string  `json:"accelerator"`
+	Architecture    string  `json:"architecture"`
+	InstanceType    string  `json:"instanceType"`
+	InstanceSize    string  `json:"instanceSize"`
+	NumAccelerators int     `json:"numAccelerators"`
+	MemoryGb        float64 `json:"memoryGb"`
+	GpuMemoryGb     float64 `json:"gpuMemoryGb"`
+	Status          string  `json:"status"`
+}
+
+// AcceleratorMemoryGb is the memory of a single accelerator of the instance.
+func (c CatalogCompute) AcceleratorMemoryGb() float64 {
+	if c.NumAccelerators < 1 {
+		return c.GpuMemoryGb
+	}
+	return c.GpuMemoryGb / float64(c.NumAccelerators)
+}
+
+// endpointCatalogURL derives the catalog URL from the endpoint API host,
+// e.g. https://api.endpoints.huggingface.cloud/v2/endpoint becomes
+// https://api.endpoints.huggingface.cloud/v2/provider.
+func endpointCatalogURL(endpointHost string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(endpointHost, "/"), "/endpoint") + "/provider"
+}
+
+func getEndpointCatalog(client *huggingface.Client) (EndpointCatalog, error) {
+	// The catalog isn't namespaced, so the request goes through a copy of the
+	// client pointed at the catalog without a namespace.
+	catalogClient := *client
+	catalogClient.HostURL = endpointCatalogURL(client.HostURL)
+	catalogClient.Namespace = ""
+
+	var catalog EndpointCatalog
+	body, statusCode, err := catalogClient.DoRequest("GET", "", nil)
+	err = decodeResponse(body, statusCode, err, &catalog, "failed to get endpoint catalog")
+	if err != nil {
+		return EndpointCatalog{}, err
+	}
+
+	return catalog, nil
+}
+
+// computes returns the available instances of a vendor, each listed once
+// however many regions offer it.
+func (c EndpointCatalog) computes(vendor string) []CatalogCompute {
+	var computes []CatalogCompute
+	seen := make(map[string]bool)
+	for _, v := range c.Vendors {
+		if !strings.EqualFold(v.Name, vendor) || v.Status != catalogStatusAvailable {
+			continue
+		}
+		for _, region := range v.Regions {
+			if region.Status != catalogStatusAvailable {
+				continue
+			}
+			for _, compute := range region.Computes {
+				key := strings.ToLower(compute.InstanceType + "/" + compute.InstanceSize)
+				if compute.Status != catalogStatusAvailable || seen[key] {
+					continue
+				}
+				seen[key] = true
+				computes = append(computes, compute)
+			}
+		}
+	}
+	return computes
+}
+
+func (c EndpointCatalog) lookupCompute(vendor string, instanceType string, instanceSize string) (CatalogCompute, bool) {
+	for _, compute := range c.computes(vendor) {
+		if strings.EqualFold(compute.InstanceType, instanceType) && strings.EqualFold(compute.InstanceSize, instanceSize) {
+			return compute, true
+		}
+	}
+	return CatalogCompute{}, false
+}
+
+// InstanceRecommendation is a catalog instance large enough for a model.
+type InstanceRecommendation struct {
+	Accelerator  string
+	InstanceType string
+	InstanceSize string
+	Accelerators int
+	MemoryGb     float64
+}
+
+func (r InstanceRecommendation) String() string {
+	return fmt.Sprintf("%s %s (%.0f GB)", r.InstanceType, r.InstanceSize, r.MemoryGb)
+}
+
+// recommendInstance returns the vendor's instance of the given accelerator
+// kind with the least memory that still fits requiredGb. For GPUs that is
+// the accelerator memory, for CPUs the RAM of the instance.
+func (c EndpointCatalog) recommendInstance(vendor string, accelerator string, requiredGb float64) (InstanceRecommendation, bool) {
+	var candidates []InstanceRecommendation
+	for _, compute := range c.computes(vendor) {
+		if !strings.EqualFold(compute.Accelerator, accelerator) {
+			continue
+		}
+		memory := compute.MemoryGb
+		if strings.EqualFold(accelerator, "gpu") {
+			memory = compute.GpuMemoryGb
+		}
+		if memory >= requiredGb {
+			candidates = append(candidates, InstanceRecommendation{
+				Accelerator:  strings.ToLower(compute.Accelerator),
+				InstanceType: compute.InstanceType,
+				InstanceSize: compute.InstanceSize,
+				Accelerators: compute.NumAccelerators,
+				MemoryGb:     memory,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		return InstanceRecommendation{}, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].MemoryGb != candidates[j].MemoryGb {
+			return candidates[i].MemoryGb < candidates[j].MemoryGb
+		}
+		return candidates[i].Accelerators < candidates[j].Accelerators
+	})
+	return candidates[0], true
+}
@@ -0,0 +1,546 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                   = &collectionResource{}
+	_ resource.ResourceWithConfigure      = &collectionResource{}
+	_ resource.ResourceWithImportState    = &collectionResource{}
+	_ resource.ResourceWithModifyPlan     = &collectionResource{}
+	_ resource.ResourceWithValidateConfig = &collectionResource{}
+)
+
+func NewCollectionResource() resource.Resource {
+	return &collectionResource{}
+}
+
+type collectionResource struct {
+	hub *hubClient
+}
+
+type collectionResourceModel struct {
+	ID          types.String     `tfsdk:"id"`
+	Title       types.String     `tfsdk:"title"`
+	Namespace   types.String     `tfsdk:"namespace"`
+	Description types.String     `tfsdk:"description"`
+	Private     types.Bool       `tfsdk:"private"`
+	Theme       types.String     `tfsdk:"theme"`
+	Items       []CollectionItem `tfsdk:"items"`
+	URL         types.String     `tfsdk:"url"`
+}
+
+type CollectionItem struct {
+	Type string  `tfsdk:"type"`
+	ID   string  `tfsdk:"id"`
+	Note *string `tfsdk:"note"`
+}
+
+func (r *collectionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *collectionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_collection"
+}
+
+func (r *collectionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a collection of models, datasets, Spaces and papers. Changes to `items` are applied one by one: only removed, added, re-noted or moved items are touched.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The collection slug. The Hub derives it from the title, so changing `title` changes it.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"title": schema.StringAttribute{
+				MarkdownDescription: "The collection title.",
+				Required:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The owning user or organization. Defaults to the provider `namespace`. Changing it replaces the collection.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The collection description.",
+				Optional:            true,
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the collection is private. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"theme": schema.StringAttribute{
+				MarkdownDescription: "The collection theme: \"orange\", \"blue\", \"green\", \"purple\", \"pink\" or \"indigo\".",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(collectionThemes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				MarkdownDescription: "The ordered items of the collection. Each item can only be listed once. When omitted, items are left alone.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The item type: \"model\", \"dataset\", \"space\" or \"paper\".",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(collectionItemTypes...),
+							},
+						},
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The repository ID, or the arXiv ID of a paper. IDs are matched case-insensitively, as on the Hub.",
+							Required:            true,
+						},
+						"note": schema.StringAttribute{
+							MarkdownDescription: "A note shown with the item.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The collection page on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// collectionItemConfig is a collection item as configured, where the values
+// may not be known yet.
+type collectionItemConfig struct {
+	Type types.String `tfsdk:"type"`
+	ID   types.String `tfsdk:"id"`
+	Note types.String `tfsdk:"note"`
+}
+
+// ValidateConfig rejects items listed twice. A collection holds each item
+// once, so a duplicate would be collapsed by the Hub and never match the
+// configuration.
+func (r *collectionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var itemList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("items"), &itemList)...)
+	if resp.Diagnostics.HasError() || itemList.IsNull() || itemList.IsUnknown() {
+		return
+	}
+
+	var items []collectionItemConfig
+	resp.Diagnostics.Append(itemList.ElementsAs(ctx, &items, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]int)
+	for i, item := range items {
+		if item.Type.IsUnknown() || item.ID.IsUnknown() || item.Type.IsNull() || item.ID.IsNull() {
+			continue
+		}
+		key := collectionItemKey(item.Type.ValueString(), item.ID.ValueString())
+		if first, ok := seen[key]; ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("items").AtListIndex(i),
+				"duplicate collection item",
+				fmt.Sprintf("%s %q is already listed at index %d; a collection holds each item once.", item.Type.ValueString(), item.ID.ValueString(), first),
+			)
+			continue
+		}
+		seen[key] = i
+	}
+}
+
+// collectionItemKey identifies an item of a collection. Repository IDs are
+// case-insensitive on the Hub, so "Org/Model" and "org/model" are the same
+// item.
+func collectionItemKey(itemType string, id string) string {
+	return itemType + "/" + strings.ToLower(id)
+}
+
+// ModifyPlan marks the slug and URL as changing along with the title, since
+// the Hub derives the slug from it.
+func (r *collectionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planTitle, stateTitle types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("title"), &planTitle)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("title"), &stateTitle)...)
+	if resp.Diagnostics.HasError() || planTitle.Equal(stateTitle) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("url"), types.StringUnknown())...)
+}
+
+// syncCollectionItems brings the items of the collection in line with items
+// one change at a time: items that are no longer wanted are removed, new ones
+// added, notes edited, and finally items are moved into the configured
+// order. Items that are already in place are not touched.
+func (r *collectionResource) syncCollectionItems(slug string, items []CollectionItem) error {
+	collection, err := r.hub.GetCollection(slug)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]CollectionItem, len(items))
+	for _, item := range items {
+		wanted[collectionItemKey(item.Type, item.ID)] = item
+	}
+
+	existing := make(map[string]HubCollectionItem)
+	for _, item := range collection.Items {
+		key := collectionItemKey(item.Type, item.ID)
+		if _, ok := wanted[key]; !ok {
+			err = r.hub.DeleteCollectionItem(slug, item.ObjectID)
+			if err != nil {
+				return fmt.Errorf("could not remove %s: %w", key, err)
+			}
+			continue
+		}
+		existing[key] = item
+	}
+
+	added := false
+	for _, item := range items {
+		key := collectionItemKey(item.Type, item.ID)
+		current, ok := existing[key]
+		if !ok {
+			err = r.hub.AddCollectionItem(slug, HubAddCollectionItemRequest{
+				Item: HubCollectionItemRef{Type: item.Type, ID: item.ID},
+				Note: item.Note,
+			})
+			if err != nil {
+				return fmt.Errorf("could not add %s: %w", key, err)
+			}
+			added = true
+			continue
+		}
+
+		note := ""
+		if item.Note != nil {
+			note = *item.Note
+		}
+		if note != current.NoteText() {
+			err = r.hub.UpdateCollectionItem(slug, current.ObjectID, HubUpdateCollectionItemRequest{Note: &note})
+			if err != nil {
+				return fmt.Errorf("could not update the note of %s: %w", key, err)
+			}
+		}
+	}
+
+	// New items are appended, so their ids are only known after a re-read.
+	if added || len(existing) != len(collection.Items) {
+		collection, err = r.hub.GetCollection(slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	order := collection.SortedItems()
+	for position, item := range items {
+		key := collectionItemKey(item.Type, item.ID)
+		from := -1
+		for i, current := range order {
+			if collectionItemKey(current.Type, current.ID) == key {
+				from = i
+				break
+			}
+		}
+		if from < 0 || from == position {
+			continue
+		}
+
+		moving := order[from]
+		err = r.hub.UpdateCollectionItem(slug, moving.ObjectID, HubUpdateCollectionItemRequest{Position: &position})
+		if err != nil {
+			return fmt.Errorf("could not move %s: %w", key, err)
+		}
+
+		// Mirror the move locally: the items in between shift by one.
+		order = append(order[:from], order[from+1:]...)
+		order = append(order[:position], append([]HubCollectionItem{moving}, order[position:]...)...)
+	}
+
+	return nil
+}
+
+func (r *collectionResource) hubCollectionToCollectionResource(collection HubCollection, prior collectionResourceModel) collectionResourceModel {
+	state := collectionResourceModel{
+		ID:        types.StringValue(collection.Slug),
+		Title:     types.StringValue(collection.Title),
+		Namespace: types.StringValue(collection.Owner.Name),
+		Private:   types.BoolValue(collection.Private),
+		Theme:     types.StringValue(collection.Theme),
+		URL:       types.StringValue(r.hub.HostURL + "/collections/" + collection.Slug),
+	}
+
+	// The Hub reports a missing description or note as an empty string.
+	if collection.Description != "" || !prior.Description.IsNull() {
+		state.Description = types.StringValue(collection.Description)
+	}
+
+	// Without items in the configuration, the items aren't managed.
+	if prior.Items == nil {
+		return state
+	}
+
+	// Items keep the ID as configured when it only differs from the Hub's
+	// in case.
+	priorItems := make(map[string]CollectionItem, len(prior.Items))
+	for _, item := range prior.Items {
+		priorItems[collectionItemKey(item.Type, item.ID)] = item
+	}
+	for _, item := range collection.SortedItems() {
+		id := item.ID
+		priorItem, ok := priorItems[collectionItemKey(item.Type, item.ID)]
+		if ok {
+			id = priorItem.ID
+		}
+		var note *string
+		if text := item.NoteText(); text != "" || priorItem.Note != nil {
+			note = &text
+		}
+		state.Items = append(state.Items, CollectionItem{
+			Type: item.Type,
+			ID:   id,
+			Note: note,
+		})
+	}
+	if state.Items == nil {
+		state.Items = []CollectionItem{}
+	}
+
+	return state
+}
+
+func (r *collectionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan collectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := plan.Namespace.ValueString()
+	if plan.Namespace.IsUnknown() || plan.Namespace.IsNull() || namespace == "" {
+		namespace = r.hub.Namespace
+	}
+
+	collection, err := r.hub.CreateCollection(HubCreateCollectionRequest{
+		Title:       plan.Title.ValueString(),
+		Namespace:   namespace,
+		Description: plan.Description.ValueStringPointer(),
+		Private:     plan.Private.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating collection",
+			err.Error(),
+		)
+		return
+	}
+	slug := collection.Slug
+
+	if !plan.Theme.IsUnknown() && !plan.Theme.IsNull() {
+		err = r.hub.UpdateCollection(slug, HubUpdateCollectionRequest{Theme: plan.Theme.ValueStringPointer()})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating collection",
+				"collection "+slug+" was created but its theme could not be set: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if plan.Items != nil {
+		err = r.syncCollectionItems(slug, plan.Items)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating collection items",
+				"collection "+slug+" was created but its items could not be added: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	collection, err = r.hub.GetCollection(slug)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading collection",
+			"could not read collection "+slug+": "+err.Error(),
+		)
+		return
+	}
+
+	state := r.hubCollectionToCollectionResource(collection, plan)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *collectionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state collectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	collection, err := r.hub.GetCollection(state.ID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading collection",
+				"could not read collection "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	newState := r.hubCollectionToCollectionResource(collection, state)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *collectionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state collectionResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	slug := state.ID.ValueString()
+	update := HubUpdateCollectionRequest{
+		Title:   plan.Title.ValueStringPointer(),
+		Private: plan.Private.ValueBoolPointer(),
+	}
+	// Removing the description clears it.
+	description := plan.Description.ValueString()
+	update.Description = &description
+	if !plan.Theme.IsUnknown() && !plan.Theme.IsNull() {
+		update.Theme = plan.Theme.ValueStringPointer()
+	}
+
+	err := r.hub.UpdateCollection(slug, update)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating collection",
+			err.Error(),
+		)
+		return
+	}
+
+	if plan.Items != nil {
+		err = r.syncCollectionItems(slug, plan.Items)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating collection items",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	collection, err := r.hub.GetCollection(slug)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading collection",
+			"could not read collection "+slug+": "+err.Error(),
+		)
+		return
+	}
+
+	// The Hub rewrites the slug when the title changes; the state follows it,
+	// as Read would.
+	newState := r.hubCollectionToCollectionResource(collection, plan)
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *collectionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state collectionResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteCollection(state.ID.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting collection",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *collectionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
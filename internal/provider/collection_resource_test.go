@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeCollectionHub serves a single collection and applies item changes the
+// way the Hub does: added items are appended, and a moved item is inserted
+// at its new position, shifting the items in between.
+type fakeCollectionHub struct {
+	slug   string
+	items  []HubCollectionItem
+	nextID int
+	calls  []string
+}
+
+func (h *fakeCollectionHub) item(objectID string) (int, bool) {
+	for i, item := range h.items {
+		if item.ObjectID == objectID {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func (h *fakeCollectionHub) renumber() {
+	for i := range h.items {
+		h.items[i].Position = i
+	}
+}
+
+func (h *fakeCollectionHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	prefix := "/api/collections/" + h.slug
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == prefix:
+		_ = json.NewEncoder(w).Encode(HubCollection{Slug: h.slug, Items: h.items})
+		return
+
+	case r.Method == http.MethodPost && r.URL.Path == prefix+"/item":
+		var request HubAddCollectionItemRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		h.nextID++
+		item := HubCollectionItem{ObjectID: fmt.Sprintf("new%d", h.nextID), Type: request.Item.Type, ID: request.Item.ID}
+		if request.Note != nil {
+			item.Note = &HubCollectionItemNote{Text: *request.Note}
+		}
+		h.items = append(h.items, item)
+		h.renumber()
+		h.calls = append(h.calls, "add "+item.Type+"/"+item.ID)
+		return
+
+	case strings.HasPrefix(r.URL.Path, prefix+"/items/"):
+		i, ok := h.item(strings.TrimPrefix(r.URL.Path, prefix+"/items/"))
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		item := h.items[i]
+		key := item.Type + "/" + item.ID
+
+		switch r.Method {
+		case http.MethodDelete:
+			h.items = append(h.items[:i], h.items[i+1:]...)
+			h.renumber()
+			h.calls = append(h.calls, "remove "+key)
+			return
+
+		case http.MethodPatch:
+			var request HubUpdateCollectionItemRequest
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			if request.Note != nil {
+				h.items[i].Note = &HubCollectionItemNote{Text: *request.Note}
+				h.calls = append(h.calls, "note "+key)
+			}
+			if request.Position != nil {
+				position := *request.Position
+				h.items = append(h.items[:i], h.items[i+1:]...)
+				h.items = append(h.items[:position], append([]HubCollectionItem{item}, h.items[position:]...)...)
+				h.renumber()
+				h.calls = append(h.calls, fmt.Sprintf("move %s to %d", key, position))
+			}
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func TestSyncCollectionItems(t *testing.T) {
+	note := func(text string) *string { return &text }
+	model := func(id string) CollectionItem { return CollectionItem{Type: "model", ID: id} }
+
+	tests := map[string]struct {
+		existing  []string
+		items     []CollectionItem
+		wantOrder []string
+		wantCalls []string
+	}{
+		"unchanged": {
+			existing:  []string{"acme/a", "acme/b", "acme/c"},
+			items:     []CollectionItem{model("acme/a"), model("acme/b"), model("acme/c")},
+			wantOrder: []string{"model/acme/a", "model/acme/b", "model/acme/c"},
+		},
+		"last item moved first": {
+			existing:  []string{"acme/a", "acme/b", "acme/c"},
+			items:     []CollectionItem{model("acme/c"), model("acme/a"), model("acme/b")},
+			wantOrder: []string{"model/acme/c", "model/acme/a", "model/acme/b"},
+			wantCalls: []string{"move model/acme/c to 0"},
+		},
+		"reversed": {
+			existing:  []string{"acme/a", "acme/b", "acme/c"},
+			items:     []CollectionItem{model("acme/c"), model("acme/b"), model("acme/a")},
+			wantOrder: []string{"model/acme/c", "model/acme/b", "model/acme/a"},
+			wantCalls: []string{"move model/acme/c to 0", "move model/acme/b to 1"},
+		},
+		"removed, added and moved": {
+			existing:  []string{"acme/a", "acme/b", "acme/c"},
+			items:     []CollectionItem{model("acme/d"), model("acme/c"), model("acme/a")},
+			wantOrder: []string{"model/acme/d", "model/acme/c", "model/acme/a"},
+			wantCalls: []string{"remove model/acme/b", "add model/acme/d", "move model/acme/d to 0", "move model/acme/c to 1"},
+		},
+		"note edited": {
+			existing:  []string{"acme/a", "acme/b"},
+			items:     []CollectionItem{model("acme/a"), {Type: "model", ID: "acme/b", Note: note("the small one")}},
+			wantOrder: []string{"model/acme/a", "model/acme/b"},
+			wantCalls: []string{"note model/acme/b"},
+		},
+		"id differing in case": {
+			existing:  []string{"Acme/A", "acme/b"},
+			items:     []CollectionItem{model("acme/a"), model("acme/b")},
+			wantOrder: []string{"model/Acme/A", "model/acme/b"},
+		},
+		"same id as another type": {
+			existing:  []string{"acme/a"},
+			items:     []CollectionItem{{Type: "dataset", ID: "acme/a"}},
+			wantOrder: []string{"dataset/acme/a"},
+			wantCalls: []string{"remove model/acme/a", "add dataset/acme/a"},
+		},
+		"emptied": {
+			existing:  []string{"acme/a", "acme/b"},
+			items:     []CollectionItem{},
+			wantCalls: []string{"remove model/acme/a", "remove model/acme/b"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			hub := &fakeCollectionHub{slug: "acme/picks-0123"}
+			for i, id := range test.existing {
+				hub.items = append(hub.items, HubCollectionItem{ObjectID: fmt.Sprintf("old%d", i), Type: "model", ID: id, Position: i})
+			}
+			server := httptest.NewServer(hub)
+			defer server.Close()
+
+			r := &collectionResource{hub: newHubClient(server.URL, "acme", "token")}
+			err := r.syncCollectionItems(hub.slug, test.items)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var order []string
+			for _, item := range hub.items {
+				order = append(order, item.Type+"/"+item.ID)
+			}
+			if !reflect.DeepEqual(order, test.wantOrder) {
+				t.Errorf("order = %q, want %q", order, test.wantOrder)
+			}
+			if !reflect.DeepEqual(hub.calls, test.wantCalls) {
+				t.Errorf("calls = %q, want %q", hub.calls, test.wantCalls)
+			}
+		})
+	}
+}
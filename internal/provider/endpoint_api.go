@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+// Endpoint states reported in status.state.
+const (
+	endpointStatePending      = "pending"
+	endpointStateInitializing = "initializing"
+	endpointStateUpdating     = "updating"
+	endpointStateRunning      = "running"
+	endpointStateFailed       = "failed"
+)
+
+// endpointBusyStates are the states an endpoint moves out of on its own, and
+// in which it may refuse to be updated.
+var endpointBusyStates = map[string]bool{
+	endpointStatePending:      true,
+	endpointStateInitializing: true,
+	endpointStateUpdating:     true,
+}
+
+// isEndpointBusyError reports whether err is the API refusing a change because
+// the endpoint is still applying a previous one.
+func isEndpointBusyError(err error) bool {
+	httpErr, ok := err.(*huggingface.HTTPError)
+	if !ok {
+		return false
+	}
+	if httpErr.StatusCode == http.StatusConflict {
+		return true
+	}
+	return httpErr.StatusCode < 500 && httpErr.Body != nil && strings.Contains(strings.ToLower(*httpErr.Body), "in progress")
+}
+
+var endpointTypes = []string{"public", "protected", "private"}
+
+var endpointAccelerators = []string{"cpu", "gpu", "neuron"}
+
+var endpointFrameworks = []string{"pytorch", "tensorflow", "custom"}
+
+// tgiQuantizeOptions are the --quantize values text-generation-inference
+// accepts.
+var tgiQuantizeOptions = []string{
+	"awq", "bitsandbytes", "bitsandbytes-nf4", "bitsandbytes-fp4", "eetq", "exl2", "fp8", "gptq", "marlin",
+}
+
+var teiPoolingOptions = []string{"cls", "mean", "splade", "last-token"}
+
+var vllmKvCacheDtypes = []string{"auto", "fp8", "fp8_e4m3", "fp8_e5m2"}
+
+var tgiNeuronAutoCastTypes = []string{"bf16", "fp16"}
+
+// The types below extend the huggingface-endpoints-client-go models with the
+// fields that package doesn't know about. The embedded request and response
+// types keep their JSON layout, so the extra fields are simply added to or
+// read from the same objects.
+
+type EndpointDetails struct {
+	huggingface.EndpointDetails
+	Private *EndpointPrivateService `json:"private,omitempty"`
+	Status  EndpointStatus          `json:"status"`
+}
+
+type EndpointStatus struct {
+	huggingface.Status
+	ErrorMessage string                `json:"errorMessage"`
+	URL          string                `json:"url"`
+	Private      EndpointPrivateStatus `json:"private"`
+}
+
+type EndpointPrivateStatus struct {
+	ServiceName string `json:"serviceName"`
+}
+
+// EndpointPrivateService is the PrivateLink configuration of a private
+// endpoint: the cloud account allowed to connect to it, and whether the
+// service is shared with other endpoints in the same account and region.
+type EndpointPrivateService struct {
+	AccountId string `json:"accountId"`
+	Shared    bool   `json:"shared"`
+}
+
+type CreateEndpointRequest struct {
+	huggingface.CreateEndpointRequest
+	Private *EndpointPrivateService `json:"private,omitempty"`
+}
+
+// UpdateEndpointRequest also carries the top-level accountId, which the API
+// accepts on updates as well as on creation.
+type UpdateEndpointRequest struct {
+	huggingface.UpdateEndpointRequest
+	AccountId *string                 `json:"accountId,omitempty"`
+	Private   *EndpointPrivateService `json:"private,omitempty"`
+}
+
+func getEndpoint(client *huggingface.Client, name string) (EndpointDetails, error) {
+	var endpoint EndpointDetails
+	body, statusCode, err := client.DoRequest("GET", name, nil)
+	err = decodeResponse(body, statusCode, err, &endpoint, "failed to get endpoint")
+	if err != nil {
+		return EndpointDetails{}, err
+	}
+
+	return endpoint, nil
+}
+
+func createEndpoint(client *huggingface.Client, request CreateEndpointRequest) (EndpointDetails, error) {
+	var endpoint EndpointDetails
+	body, statusCode, err := client.DoRequest("POST", "", request)
+	err = decodeResponse(body, statusCode, err, &endpoint, "failed to create endpoint")
+	if err != nil {
+		return EndpointDetails{}, err
+	}
+
+	return endpoint, nil
+}
+
+func updateEndpoint(client *huggingface.Client, name string, request UpdateEndpointRequest) (EndpointDetails, error) {
+	var endpoint EndpointDetails
+	body, statusCode, err := client.DoRequest("PUT", name, request)
+	err = decodeResponse(body, statusCode, err, &endpoint, "failed to update endpoint")
+	if err != nil {
+		return EndpointDetails{}, err
+	}
+
+	return endpoint, nil
+}
+
+func pauseEndpoint(client *huggingface.Client, name string) error {
+	body, statusCode, err := client.DoRequest("POST", name+"/pause", nil)
+	return decodeResponse(body, statusCode, err, nil, "failed to pause endpoint")
+}
+
+func scaleEndpointToZero(client *huggingface.Client, name string) error {
+	body, statusCode, err := client.DoRequest("POST", name+"/scale-to-zero", nil)
+	return decodeResponse(body, statusCode, err, nil, "failed to scale endpoint to zero")
+}
+
+// endpointLogLines is how many of the most recent log lines are included in
+// the diagnostics of a failed endpoint.
+const endpointLogLines = 50
+
+// getEndpointLogs returns up to the last lines lines of the endpoint's
+// container logs. It relies on GET {name}/logs?tail=N of the endpoint API,
+// which answers with the logs as plain text, one entry per line and oldest
+// first, rather than JSON. tail is only treated as a hint: the result is cut
+// down to lines here as well. A body that isn't text, such as an HTML error
+// page, is reported as unparseable rather than returned as logs.
+func getEndpointLogs(client *huggingface.Client, name string, lines int) ([]string, error) {
+	body, statusCode, err := client.DoRequest("GET", fmt.Sprintf("%s/logs?tail=%d", name, lines), nil)
+	err = decodeResponse(body, statusCode, err, nil, "failed to get endpoint logs")
+	if err != nil {
+		return nil, err
+	}
+	if !utf8.Valid(body) || strings.HasPrefix(strings.TrimSpace(string(body)), "<") {
+		return nil, fmt.Errorf("%w: endpoint logs are not plain text", huggingface.ErrUnmarshalingResponse)
+	}
+
+	text := strings.TrimRight(string(body), "\n")
+	if text == "" {
+		return nil, nil
+	}
+
+	logs := strings.Split(text, "\n")
+	if len(logs) > lines {
+		logs = logs[len(logs)-lines:]
+	}
+	return logs, nil
+}
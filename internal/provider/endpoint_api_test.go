@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+func TestIsEndpointBusyError(t *testing.T) {
+	httpError := func(statusCode int, body string) error {
+		return &huggingface.HTTPError{StatusCode: statusCode, Body: &body, Message: "failed to update endpoint"}
+	}
+
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"conflict": {
+			err:  httpError(http.StatusConflict, ""),
+			want: true,
+		},
+		"conflict without body": {
+			err:  &huggingface.HTTPError{StatusCode: http.StatusConflict},
+			want: true,
+		},
+		"bad request with update in progress": {
+			err:  httpError(http.StatusBadRequest, `{"error":"An update is already in progress"}`),
+			want: true,
+		},
+		"in progress in upper case": {
+			err:  httpError(http.StatusBadRequest, "Deployment IN PROGRESS"),
+			want: true,
+		},
+		"bad request without body": {
+			err: &huggingface.HTTPError{StatusCode: http.StatusBadRequest},
+		},
+		"bad request for another reason": {
+			err: httpError(http.StatusBadRequest, `{"error":"invalid instance type"}`),
+		},
+		"not found": {
+			err: httpError(http.StatusNotFound, "endpoint not found"),
+		},
+		"server error with in progress": {
+			err: httpError(http.StatusInternalServerError, "operation in progress"),
+		},
+		"bad gateway": {
+			err: httpError(http.StatusBadGateway, ""),
+		},
+		"not an http error": {
+			err: errors.New("update in progress"),
+		},
+		"nil": {
+			err: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := isEndpointBusyError(test.err); got != test.want {
+				t.Errorf("isEndpointBusyError(%v) = %t, want %t", test.err, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	hubValidationOff    = "off"
+	hubValidationWarn   = "warn"
+	hubValidationStrict = "strict"
+)
+
+// hubValidationReporter raises plan-time findings either as warnings or, in
+// strict mode, as errors that stop the plan.
+type hubValidationReporter struct {
+	mode  string
+	diags *diag.Diagnostics
+}
+
+func (r hubValidationReporter) report(attributePath path.Path, summary string, detail string) {
+	if r.mode == hubValidationStrict {
+		r.diags.AddAttributeError(attributePath, summary, detail)
+		return
+	}
+	r.diags.AddAttributeWarning(attributePath, summary, detail)
+}
+
+// compatibleTasks lists the endpoint tasks that can serve a model whose Hub
+// pipeline tag differs from the task name, e.g. sentence-transformers models
+// tagged "sentence-similarity" that are deployed as "sentence-embeddings".
+var compatibleTasks = map[string][]string{
+	"sentence-similarity":  {"sentence-embeddings", "feature-extraction", "sentence-ranking"},
+	"feature-extraction":   {"sentence-embeddings", "sentence-similarity"},
+	"text2text-generation": {"text-generation"},
+	"image-text-to-text":   {"text-generation"},
+	"text-classification":  {"sentence-ranking"},
+}
+
+func isCompatibleTask(task string, pipelineTag string) bool {
+	if strings.EqualFold(task, pipelineTag) {
+		return true
+	}
+	for _, compatible := range compatibleTasks[pipelineTag] {
+		if strings.EqualFold(task, compatible) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkEndpointModelMetadata compares the task and framework an endpoint is
+// configured with against what the Hub knows about the model repository.
+// Custom tasks and frameworks are never flagged since the container decides
+// how the model is served.
+func checkEndpointModelMetadata(model HubModel, task string, framework string, reporter hubValidationReporter) {
+	if task != "" && task != "custom" && model.PipelineTag != "" && !isCompatibleTask(task, model.PipelineTag) {
+		reporter.report(
+			path.Root("model").AtName("task"),
+			"endpoint task does not match the model",
+			fmt.Sprintf(
+				"model.task is %q but %s is tagged %q on the hub. The endpoint is likely to fail to start or to return unexpected results.",
+				task, model.ID, model.PipelineTag,
+			),
+		)
+	}
+
+	frameworks := hubModelFrameworks(model)
+	if framework == "" || framework == "custom" || len(frameworks) == 0 {
+		return
+	}
+	for _, hubFramework := range frameworks {
+		if strings.EqualFold(framework, hubFramework) {
+			return
+		}
+	}
+	library := model.LibraryName
+	if library == "" {
+		library = "no library"
+	}
+	reporter.report(
+		path.Root("model").AtName("framework"),
+		"endpoint framework does not match the model",
+		fmt.Sprintf(
+			"model.framework is %q but %s (%s) only ships %s weights. The endpoint is likely to fail to load the model.",
+			framework, model.ID, library, strings.Join(frameworks, " and "),
+		),
+	)
+}
+
+// kvCacheHeadroom is the share of memory needed on top of the weights for
+// the KV cache, activations and the CUDA context.
+const kvCacheHeadroom = 0.25
+
+const bytesPerGb = 1 << 30
+
+var dtypeBytes = map[string]float64{
+	"F64":     8,
+	"I64":     8,
+	"F32":     4,
+	"I32":     4,
+	"F16":     2,
+	"BF16":    2,
+	"I16":     2,
+	"F8_E4M3": 1,
+	"F8_E5M2": 1,
+	"I8":      1,
+	"U8":      1,
+	"BOOL":    1,
+}
+
+// quantizeBytes is the per-parameter size of the weights once TGI has
+// quantized them at load time.
+var quantizeBytes = map[string]float64{
+	"bitsandbytes":     1,
+	"eetq":             1,
+	"fp8":              1,
+	"bitsandbytes-nf4": 0.5,
+	"bitsandbytes-fp4": 0.5,
+	"gptq":             0.5,
+	"awq":              0.5,
+	"marlin":           0.5,
+	"exl2":             0.5,
+}
+
+// endpointHardware is the part of an endpoint plan the hardware fit check
+// looks at.
+type endpointHardware struct {
+	Vendor             string
+	Accelerator        string
+	InstanceType       string
+	InstanceSize       string
+	Quantize           string
+	Vllm               bool
+	TensorParallelSize int64
+}
+
+// estimateModelMemoryGb estimates the accelerator memory needed to serve a
+// model, including headroom for the KV cache.
+func estimateModelMemoryGb(safetensors HubSafetensors, quantize string) float64 {
+	var weights float64
+	if size, ok := quantizeBytes[strings.ToLower(quantize)]; ok {
+		weights = float64(safetensors.Total) * size
+	} else {
+		for dtype, count := range safetensors.Parameters {
+			size, ok := dtypeBytes[strings.ToUpper(dtype)]
+			if !ok {
+				size = 2
+			}
+			weights += float64(count) * size
+		}
+	}
+	return weights * (1 + kvCacheHeadroom) / bytesPerGb
+}
+
+// checkEndpointHardwareFit compares the estimated memory footprint of the
+// model with the memory of the accelerators it will be sharded over. Only GPU
+// instances the catalog lists for the vendor are checked.
+func checkEndpointHardwareFit(model HubModel, hardware endpointHardware, catalog EndpointCatalog, reporter hubValidationReporter) {
+	if model.Safetensors == nil || model.Safetensors.Total == 0 || !strings.EqualFold(hardware.Accelerator, "gpu") {
+		return
+	}
+	compute, ok := catalog.lookupCompute(hardware.Vendor, hardware.InstanceType, hardware.InstanceSize)
+	if !ok || compute.NumAccelerators < 1 {
+		return
+	}
+	count := compute.NumAccelerators
+
+	// TGI and the default container shard over every accelerator on the
+	// instance, while vLLM only uses tensor_parallel_size of them.
+	shards := count
+	if hardware.Vllm {
+		shards = 1
+		if hardware.TensorParallelSize > 0 {
+			shards = int(hardware.TensorParallelSize)
+		}
+		if shards > count {
+			reporter.report(
+				path.Root("model").AtName("image").AtName("vllm").AtName("tensor_parallel_size"),
+				"tensor parallel size exceeds the instance accelerators",
+				fmt.Sprintf(
+					"vllm.tensor_parallel_size is %d but %s %s only has %d accelerators.",
+					shards, hardware.InstanceType, hardware.InstanceSize, count,
+				),
+			)
+			return
+		}
+	}
+
+	requiredGb := estimateModelMemoryGb(*model.Safetensors, hardware.Quantize)
+	availableGb := compute.AcceleratorMemoryGb() * float64(shards)
+	if requiredGb <= availableGb {
+		return
+	}
+
+	detail := fmt.Sprintf(
+		"%s has %d parameters stored as %s and needs an estimated %.1f GB of accelerator memory including KV cache headroom, but %s %s provides %.0f GB",
+		model.ID, model.Safetensors.Total, model.Safetensors.Dtype(), requiredGb, hardware.InstanceType, hardware.InstanceSize, availableGb,
+	)
+	if hardware.Vllm && shards < count {
+		detail += fmt.Sprintf(" on the %d of %d accelerators vLLM uses", shards, count)
+	}
+	detail += ". The endpoint is likely to run out of memory while loading the model."
+
+	if hardware.Vllm && shards < count && compute.GpuMemoryGb >= requiredGb {
+		detail += fmt.Sprintf(" Consider vllm.tensor_parallel_size = %d to shard the model over all of them.", count)
+	} else if recommendation, ok := catalog.recommendInstance(hardware.Vendor, "gpu", requiredGb); ok {
+		detail += " Consider " + recommendation.String()
+		if hardware.Vllm && recommendation.Accelerators > 1 {
+			detail += fmt.Sprintf(" with vllm.tensor_parallel_size = %d", recommendation.Accelerators)
+		}
+		detail += "."
+	} else if hardware.Quantize == "" && !hardware.Vllm {
+		detail += " No single instance in the catalog fits the model; consider tgi.quantize."
+	}
+
+	reporter.report(path.Root("compute").AtName("instance_type"), "model may not fit on the selected instance", detail)
+}
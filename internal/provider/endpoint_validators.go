@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Endpoint names end up in the endpoint's hostname, so they are limited to
+// lowercase letters, digits and dashes, and can't start or end with a dash.
+const endpointNameMaxLength = 32
+
+var endpointNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+var endpointNamePrefixPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// endpointNameSuffixLength is the number of random characters name_prefix
+// is completed with.
+const endpointNameSuffixLength = 8
+
+// generateEndpointName returns prefix followed by a random suffix.
+func generateEndpointName(prefix string) (string, error) {
+	suffix := make([]byte, endpointNameSuffixLength/2)
+	_, err := rand.Read(suffix)
+	if err != nil {
+		return "", fmt.Errorf("could not generate an endpoint name: %w", err)
+	}
+	return prefix + hex.EncodeToString(suffix), nil
+}
+
+var _ resource.ConfigValidator = endpointScalingValidator{}
+
+// endpointScalingValidator checks compute.scaling for combinations the API
+// rejects, which are errors, and for ones it accepts but that are unlikely to
+// do what was intended, which are warnings.
+type endpointScalingValidator struct{}
+
+func (v endpointScalingValidator) Description(_ context.Context) string {
+	return "checks that the endpoint scaling configuration is consistent"
+}
+
+func (v endpointScalingValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v endpointScalingValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	scaling := path.Root("compute").AtName("scaling")
+
+	var minReplica, maxReplica, scaleToZeroTimeout types.Int64
+	var hardwareUsage, pendingRequests types.Float64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("min_replica"), &minReplica)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("max_replica"), &maxReplica)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("scale_to_zero_timeout"), &scaleToZeroTimeout)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("measure").AtName("hardware_usage"), &hardwareUsage)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, scaling.AtName("measure").AtName("pending_requests"), &pendingRequests)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	minKnown := !minReplica.IsNull() && !minReplica.IsUnknown()
+	maxKnown := !maxReplica.IsNull() && !maxReplica.IsUnknown()
+
+	if minKnown && minReplica.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("min_replica"),
+			"invalid scaling configuration",
+			fmt.Sprintf("min_replica must not be negative, got %d.", minReplica.ValueInt64()),
+		)
+	}
+	if maxKnown && maxReplica.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("max_replica"),
+			"invalid scaling configuration",
+			fmt.Sprintf("max_replica must be at least 1, got %d.", maxReplica.ValueInt64()),
+		)
+	}
+	if minKnown && maxKnown && minReplica.ValueInt64() > maxReplica.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("min_replica"),
+			"invalid scaling configuration",
+			fmt.Sprintf("min_replica (%d) must not be greater than max_replica (%d).", minReplica.ValueInt64(), maxReplica.ValueInt64()),
+		)
+	}
+
+	if !hardwareUsage.IsNull() && !pendingRequests.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			scaling.AtName("measure"),
+			"invalid scaling configuration",
+			"An endpoint scales on a single metric: set either measure.hardware_usage or measure.pending_requests, not both.",
+		)
+	}
+
+	// The timeout only decides when an idle endpoint goes down to zero
+	// replicas, which can't happen while min_replica keeps some running.
+	if minKnown && minReplica.ValueInt64() > 0 && !scaleToZeroTimeout.IsNull() && !scaleToZeroTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeWarning(
+			scaling.AtName("scale_to_zero_timeout"),
+			"scale_to_zero_timeout has no effect",
+			fmt.Sprintf("scale_to_zero_timeout is set but min_replica is %d, so the endpoint never scales to zero. Set min_replica to 0 to enable scale to zero.", minReplica.ValueInt64()),
+		)
+	}
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// scalingTestSchema is the part of the endpoint schema endpointScalingValidator
+// reads.
+var scalingTestSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"compute": schema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]schema.Attribute{
+				"scaling": schema.SingleNestedAttribute{
+					Optional: true,
+					Attributes: map[string]schema.Attribute{
+						"min_replica":           schema.Int64Attribute{Optional: true},
+						"max_replica":           schema.Int64Attribute{Optional: true},
+						"scale_to_zero_timeout": schema.Int64Attribute{Optional: true},
+						"measure": schema.SingleNestedAttribute{
+							Optional: true,
+							Attributes: map[string]schema.Attribute{
+								"hardware_usage":   schema.Float64Attribute{Optional: true},
+								"pending_requests": schema.Float64Attribute{Optional: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+type scalingTestValues struct {
+	minReplica         tftypes.Value
+	maxReplica         tftypes.Value
+	scaleToZeroTimeout tftypes.Value
+	hardwareUsage      tftypes.Value
+	pendingRequests    tftypes.Value
+}
+
+func scalingTestConfig(ctx context.Context, values scalingTestValues) tfsdk.Config {
+	rootType := scalingTestSchema.Type().TerraformType(ctx).(tftypes.Object)
+	computeType := rootType.AttributeTypes["compute"].(tftypes.Object)
+	scalingType := computeType.AttributeTypes["scaling"].(tftypes.Object)
+	measureType := scalingType.AttributeTypes["measure"].(tftypes.Object)
+
+	measure := tftypes.NewValue(measureType, map[string]tftypes.Value{
+		"hardware_usage":   values.hardwareUsage,
+		"pending_requests": values.pendingRequests,
+	})
+	scaling := tftypes.NewValue(scalingType, map[string]tftypes.Value{
+		"min_replica":           values.minReplica,
+		"max_replica":           values.maxReplica,
+		"scale_to_zero_timeout": values.scaleToZeroTimeout,
+		"measure":               measure,
+	})
+	compute := tftypes.NewValue(computeType, map[string]tftypes.Value{
+		"scaling": scaling,
+	})
+
+	return tfsdk.Config{
+		Schema: scalingTestSchema,
+		Raw:    tftypes.NewValue(rootType, map[string]tftypes.Value{"compute": compute}),
+	}
+}
+
+func TestEndpointScalingValidator(t *testing.T) {
+	number := func(n float64) tftypes.Value { return tftypes.NewValue(tftypes.Number, n) }
+	null := tftypes.NewValue(tftypes.Number, nil)
+	unknown := tftypes.NewValue(tftypes.Number, tftypes.UnknownValue)
+
+	tests := map[string]struct {
+		values   scalingTestValues
+		errors   int
+		warnings int
+	}{
+		"valid": {
+			values: scalingTestValues{number(0), number(2), number(15), number(80), null},
+		},
+		"all null": {
+			values: scalingTestValues{null, null, null, null, null},
+		},
+		"negative min_replica": {
+			values: scalingTestValues{number(-1), number(2), null, null, null},
+			errors: 1,
+		},
+		"zero max_replica": {
+			values: scalingTestValues{number(0), number(0), null, null, null},
+			errors: 1,
+		},
+		"min_replica greater than max_replica": {
+			values: scalingTestValues{number(3), number(2), null, null, null},
+			errors: 1,
+		},
+		"unknown min_replica": {
+			values: scalingTestValues{unknown, number(2), number(15), null, null},
+		},
+		"unknown max_replica": {
+			values: scalingTestValues{number(3), unknown, null, null, null},
+		},
+		"both measures": {
+			values: scalingTestValues{number(0), number(2), null, number(80), number(1.5)},
+			errors: 1,
+		},
+		"unknown measure": {
+			values: scalingTestValues{number(0), number(2), null, number(80), unknown},
+			errors: 1,
+		},
+		"scale_to_zero_timeout with min_replica above zero": {
+			values:   scalingTestValues{number(1), number(2), number(15), null, null},
+			warnings: 1,
+		},
+		"scale_to_zero_timeout with unknown min_replica": {
+			values: scalingTestValues{unknown, number(2), number(15), null, null},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			req := resource.ValidateConfigRequest{Config: scalingTestConfig(ctx, test.values)}
+			resp := &resource.ValidateConfigResponse{}
+
+			endpointScalingValidator{}.ValidateResource(ctx, req, resp)
+
+			if got := resp.Diagnostics.ErrorsCount(); got != test.errors {
+				t.Errorf("got %d errors, want %d: %v", got, test.errors, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != test.warnings {
+				t.Errorf("got %d warnings, want %d: %v", got, test.warnings, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestGenerateEndpointName(t *testing.T) {
+	suffixPattern := regexp.MustCompile(`^[0-9a-f]{8}$`)
+
+	tests := map[string]struct {
+		prefix string
+	}{
+		"dash":        {prefix: "prod-"},
+		"no dash":     {prefix: "prod"},
+		"single char": {prefix: "a"},
+		"longest": {
+			prefix: strings.Repeat("a", endpointNameMaxLength-endpointNameSuffixLength),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if !endpointNamePrefixPattern.MatchString(test.prefix) {
+				t.Fatalf("test prefix %q isn't a valid name_prefix", test.prefix)
+			}
+
+			got, err := generateEndpointName(test.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(got, test.prefix) {
+				t.Errorf("%q doesn't start with %q", got, test.prefix)
+			}
+			if suffix := strings.TrimPrefix(got, test.prefix); !suffixPattern.MatchString(suffix) {
+				t.Errorf("suffix %q of %q isn't %d lowercase hex characters", suffix, got, endpointNameSuffixLength)
+			}
+			if len(got) > endpointNameMaxLength {
+				t.Errorf("%q is longer than %d characters", got, endpointNameMaxLength)
+			}
+			if !endpointNamePattern.MatchString(got) {
+				t.Errorf("%q isn't a valid endpoint name", got)
+			}
+
+			other, err := generateEndpointName(test.prefix)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if other == got {
+				t.Errorf("two generated names are both %q", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,541 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+const HubHostURL string = "https://huggingface.co"
+
+// hubClient talks to the Hugging Face Hub API, which lives on a different
+// host than the inference endpoints API and is not covered by the
+// huggingface-endpoints-client-go package.
+type hubClient struct {
+	HostURL    string
+	HTTPClient *http.Client
+	// UploadHTTPClient has no timeout, since LFS uploads of model weights,
+	// and the commits that follow them, can run far longer than any API
+	// call.
+	UploadHTTPClient *http.Client
+	Token            string
+	Namespace        string
+}
+
+func newHubClient(host, namespace, token string) *hubClient {
+	c := hubClient{
+		HTTPClient:       &http.Client{Timeout: 30 * time.Second},
+		UploadHTTPClient: &http.Client{},
+		HostURL:          HubHostURL,
+		Token:            token,
+		Namespace:        namespace,
+	}
+
+	if host != "" {
+		c.HostURL = strings.TrimSuffix(host, "/")
+	}
+
+	return &c
+}
+
+func (c *hubClient) DoRequest(method, path string, body interface{}) ([]byte, *int, error) {
+	var reqBody *bytes.Buffer
+	if body != nil {
+		reqBody = new(bytes.Buffer)
+		err := json.NewEncoder(reqBody).Encode(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", huggingface.ErrEncodingRequestBody, err)
+		}
+	} else {
+		reqBody = bytes.NewBuffer([]byte{})
+	}
+
+	headers := map[string]string{"Content-Type": "application/json"}
+	return c.doRaw(method, c.HostURL+path, reqBody, headers)
+}
+
+// doRaw sends a request with an arbitrary body to an absolute URL. It is used
+// directly for the payloads that aren't JSON, such as the form-encoded
+// paths-info lookup.
+func (c *hubClient) doRaw(method, rawURL string, body io.Reader, headers map[string]string) ([]byte, *int, error) {
+	respBody, statusCode, _, err := c.doRawWithHeader(method, rawURL, body, headers)
+	return respBody, statusCode, err
+}
+
+// doLongRaw is doRaw through UploadHTTPClient, for the requests the Hub may
+// take minutes to answer because they carry or finalize large files:
+// commits, LFS batch requests and LFS completions.
+func (c *hubClient) doLongRaw(method, rawURL string, body io.Reader, headers map[string]string) ([]byte, *int, error) {
+	respBody, statusCode, _, err := c.send(c.UploadHTTPClient, method, rawURL, body, headers)
+	return respBody, statusCode, err
+}
+
+// doRawWithHeader is doRaw for the few callers that need the response
+// headers, e.g. to follow pagination links.
+func (c *hubClient) doRawWithHeader(method, rawURL string, body io.Reader, headers map[string]string) ([]byte, *int, http.Header, error) {
+	return c.send(c.HTTPClient, method, rawURL, body, headers)
+}
+
+// send sends a request through client, authenticating it when it goes to
+// the Hub.
+func (c *hubClient) send(client *http.Client, method, rawURL string, body io.Reader, headers map[string]string) ([]byte, *int, http.Header, error) {
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", huggingface.ErrCreatingRequest, err)
+	}
+
+	// LFS uploads go to presigned storage URLs, which must not see the token.
+	if c.Token != "" && strings.HasPrefix(rawURL, c.HostURL) {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.Token))
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %v", huggingface.ErrReadingResponseBody, err)
+	}
+
+	return respBody, &res.StatusCode, res.Header, nil
+}
+
+// doJSON performs a request and decodes a successful response into out. Any
+// non-2xx status is returned as a *huggingface.HTTPError so callers can match
+// on the status code the same way they do for endpoint API errors.
+func (c *hubClient) doJSON(method, path string, body interface{}, out interface{}, message string) error {
+	respBody, statusCode, err := c.DoRequest(method, path, body)
+	return decodeResponse(respBody, statusCode, err, out, message)
+}
+
+// decodeResponse turns the result of a request into an error for failed
+// requests and non-2xx statuses, and otherwise decodes the body into out.
+func decodeResponse(respBody []byte, statusCode *int, err error, out interface{}, message string) error {
+	if err != nil {
+		return fmt.Errorf("%w: %v", huggingface.ErrExecutingRequest, err)
+	}
+	if *statusCode < 200 || *statusCode >= 300 {
+		bodyStr := string(respBody)
+		return &huggingface.HTTPError{
+			StatusCode: *statusCode,
+			Body:       &bodyStr,
+			Message:    message,
+		}
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(respBody, out)
+	if err != nil {
+		return fmt.Errorf("%w: %v", huggingface.ErrUnmarshalingResponse, err)
+	}
+
+	return nil
+}
+
+// escapeRepoID escapes each segment of a "namespace/name" repo id while
+// keeping the separating slash intact.
+func escapeRepoID(repoID string) string {
+	segments := strings.Split(repoID, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func (c *hubClient) GetModel(repoID string, revision string) (HubModel, error) {
+	path := "/api/models/" + escapeRepoID(repoID)
+	if revision != "" {
+		path += "/revision/" + url.PathEscape(revision)
+	}
+
+	var model HubModel
+	err := c.doJSON("GET", path, nil, &model, "failed to get model")
+	if err != nil {
+		return HubModel{}, err
+	}
+
+	return model, nil
+}
+
+// repoTypePath returns the API path segment for a repo type, e.g. "models"
+// for "model".
+func repoTypePath(repoType string) string {
+	if repoType == "" {
+		return "models"
+	}
+	return repoType + "s"
+}
+
+// repoTypeParam returns the repo type as expected in request bodies, where
+// models are the default and the type is left out.
+func repoTypeParam(repoType string) string {
+	if repoType == "model" {
+		return ""
+	}
+	return repoType
+}
+
+func (c *hubClient) GetRepo(repoType string, repoID string) (HubRepo, error) {
+	var repo HubRepo
+	err := c.doJSON("GET", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID), nil, &repo, "failed to get repository")
+	if err != nil {
+		return HubRepo{}, err
+	}
+
+	return repo, nil
+}
+
+// GetRepoRevision returns the repo as of a branch, tag or commit. Its Sha is
+// the commit the revision resolves to.
+func (c *hubClient) GetRepoRevision(repoType string, repoID string, revision string) (HubRepo, error) {
+	var repo HubRepo
+	err := c.doJSON("GET", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/revision/"+url.PathEscape(revision), nil, &repo, "failed to get repository revision")
+	if err != nil {
+		return HubRepo{}, err
+	}
+
+	return repo, nil
+}
+
+func (c *hubClient) CreateRepo(repo HubCreateRepoRequest) (HubCreateRepoResponse, error) {
+	var response HubCreateRepoResponse
+	err := c.doJSON("POST", "/api/repos/create", repo, &response, "failed to create repository")
+	if err != nil {
+		return HubCreateRepoResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (c *hubClient) UpdateRepoSettings(repoType string, repoID string, settings HubRepoSettings) error {
+	return c.doJSON("PUT", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/settings", settings, nil, "failed to update repository settings")
+}
+
+func (c *hubClient) DeleteRepo(repo HubDeleteRepoRequest) error {
+	return c.doJSON("DELETE", "/api/repos/delete", repo, nil, "failed to delete repository")
+}
+
+func (c *hubClient) GetRepoRefs(repoType string, repoID string) (HubRepoRefs, error) {
+	var refs HubRepoRefs
+	err := c.doJSON("GET", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/refs", nil, &refs, "failed to list repository refs")
+	if err != nil {
+		return HubRepoRefs{}, err
+	}
+
+	return refs, nil
+}
+
+// CreateBranch creates a branch at startingPoint, which can be any revision.
+// An empty startingPoint branches off the head of the default branch.
+func (c *hubClient) CreateBranch(repoType string, repoID string, branch string, startingPoint string) error {
+	request := HubCreateBranchRequest{StartingPoint: startingPoint}
+	return c.doJSON("POST", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/branch/"+url.PathEscape(branch), request, nil, "failed to create branch")
+}
+
+func (c *hubClient) DeleteBranch(repoType string, repoID string, branch string) error {
+	return c.doJSON("DELETE", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/branch/"+url.PathEscape(branch), nil, nil, "failed to delete branch")
+}
+
+func (c *hubClient) CreateTag(repoType string, repoID string, revision string, tag HubCreateTagRequest) error {
+	return c.doJSON("POST", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/tag/"+url.PathEscape(revision), tag, nil, "failed to create tag")
+}
+
+func (c *hubClient) DeleteTag(repoType string, repoID string, tag string) error {
+	return c.doJSON("DELETE", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/tag/"+url.PathEscape(tag), nil, nil, "failed to delete tag")
+}
+
+// accessRequestStatuses are the states an access request to a gated repo can
+// be in.
+var accessRequestStatuses = []string{"pending", "accepted", "rejected"}
+
+func (c *hubClient) ListAccessRequests(repoType string, repoID string, status string) ([]HubAccessRequest, error) {
+	var requests []HubAccessRequest
+	err := c.doJSON("GET", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/user-access-request/"+status, nil, &requests, "failed to list access requests")
+	if err != nil {
+		return nil, err
+	}
+
+	return requests, nil
+}
+
+// HandleAccessRequest moves the access request of a user to another status.
+// The user must have requested access before.
+func (c *hubClient) HandleAccessRequest(repoType string, repoID string, request HubHandleAccessRequest) error {
+	return c.doJSON("POST", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/user-access-request/handle", request, nil, "failed to handle access request")
+}
+
+// GrantAccess gives a user access to a gated repo without a prior request.
+func (c *hubClient) GrantAccess(repoType string, repoID string, user string) error {
+	request := HubHandleAccessRequest{User: user}
+	return c.doJSON("POST", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/user-access-request/grant", request, nil, "failed to grant access")
+}
+
+func (c *hubClient) GetSpace(repoID string) (HubSpace, error) {
+	var space HubSpace
+	err := c.doJSON("GET", "/api/spaces/"+escapeRepoID(repoID), nil, &space, "failed to get space")
+	if err != nil {
+		return HubSpace{}, err
+	}
+
+	return space, nil
+}
+
+func (c *hubClient) GetSpaceRuntime(repoID string) (HubSpaceRuntime, error) {
+	var runtime HubSpaceRuntime
+	err := c.doJSON("GET", "/api/spaces/"+escapeRepoID(repoID)+"/runtime", nil, &runtime, "failed to get space runtime")
+	if err != nil {
+		return HubSpaceRuntime{}, err
+	}
+
+	return runtime, nil
+}
+
+func (c *hubClient) DuplicateSpace(fromRepoID string, space HubDuplicateSpaceRequest) error {
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(fromRepoID)+"/duplicate", space, nil, "failed to duplicate space")
+}
+
+func (c *hubClient) RequestSpaceHardware(repoID string, hardware HubSpaceHardwareRequest) error {
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(repoID)+"/hardware", hardware, nil, "failed to request space hardware")
+}
+
+func (c *hubClient) SetSpaceSleepTime(repoID string, seconds int64) error {
+	body := map[string]int64{"seconds": seconds}
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(repoID)+"/sleeptime", body, nil, "failed to set space sleep time")
+}
+
+func (c *hubClient) RequestSpaceStorage(repoID string, tier string) error {
+	body := map[string]string{"tier": tier}
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(repoID)+"/storage", body, nil, "failed to request space storage")
+}
+
+func (c *hubClient) DeleteSpaceStorage(repoID string) error {
+	return c.doJSON("DELETE", "/api/spaces/"+escapeRepoID(repoID)+"/storage", nil, nil, "failed to delete space storage")
+}
+
+func (c *hubClient) AddSpaceSecret(repoID string, secret HubSpaceKeyValue) error {
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(repoID)+"/secrets", secret, nil, "failed to add space secret")
+}
+
+func (c *hubClient) DeleteSpaceSecret(repoID string, key string) error {
+	body := map[string]string{"key": key}
+	return c.doJSON("DELETE", "/api/spaces/"+escapeRepoID(repoID)+"/secrets", body, nil, "failed to delete space secret")
+}
+
+func (c *hubClient) GetSpaceVariables(repoID string) (map[string]HubSpaceVariable, error) {
+	var variables map[string]HubSpaceVariable
+	err := c.doJSON("GET", "/api/spaces/"+escapeRepoID(repoID)+"/variables", nil, &variables, "failed to get space variables")
+	if err != nil {
+		return nil, err
+	}
+
+	return variables, nil
+}
+
+func (c *hubClient) AddSpaceVariable(repoID string, variable HubSpaceKeyValue) error {
+	return c.doJSON("POST", "/api/spaces/"+escapeRepoID(repoID)+"/variables", variable, nil, "failed to add space variable")
+}
+
+func (c *hubClient) DeleteSpaceVariable(repoID string, key string) error {
+	body := map[string]string{"key": key}
+	return c.doJSON("DELETE", "/api/spaces/"+escapeRepoID(repoID)+"/variables", body, nil, "failed to delete space variable")
+}
+
+type HubSpaceKeyValue struct {
+	Key         string  `json:"key"`
+	Value       string  `json:"value"`
+	Description *string `json:"description,omitempty"`
+}
+
+type HubSpaceVariable struct {
+	Value       string  `json:"value"`
+	Description *string `json:"description,omitempty"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+type HubSpace struct {
+	ID           string          `json:"id"`
+	Author       string          `json:"author"`
+	Sha          string          `json:"sha"`
+	LastModified string          `json:"lastModified"`
+	Private      bool            `json:"private"`
+	Sdk          string          `json:"sdk"`
+	Host         string          `json:"host"`
+	Runtime      HubSpaceRuntime `json:"runtime"`
+}
+
+type HubSpaceRuntime struct {
+	Stage        string           `json:"stage"`
+	Hardware     HubSpaceHardware `json:"hardware"`
+	Storage      *string          `json:"storage"`
+	GcTimeout    *int64           `json:"gcTimeout"`
+	ErrorMessage string           `json:"errorMessage"`
+}
+
+type HubSpaceHardware struct {
+	Current   *string `json:"current"`
+	Requested *string `json:"requested"`
+}
+
+type HubDuplicateSpaceRequest struct {
+	Repository       string `json:"repository"`
+	Private          bool   `json:"private"`
+	Hardware         string `json:"hardware,omitempty"`
+	StorageTier      string `json:"storageTier,omitempty"`
+	SleepTimeSeconds *int64 `json:"sleepTimeSeconds,omitempty"`
+}
+
+type HubSpaceHardwareRequest struct {
+	Flavor           string `json:"flavor"`
+	SleepTimeSeconds *int64 `json:"sleepTimeSeconds,omitempty"`
+}
+
+type HubRepo struct {
+	ID                  string               `json:"id"`
+	Author              string               `json:"author"`
+	Sha                 string               `json:"sha"`
+	LastModified        string               `json:"lastModified"`
+	Private             bool                 `json:"private"`
+	Gated               interface{}          `json:"gated"`
+	Disabled            bool                 `json:"disabled"`
+	DiscussionsDisabled *bool                `json:"discussionsDisabled,omitempty"`
+	ResourceGroup       *HubResourceGroupRef `json:"resourceGroup,omitempty"`
+}
+
+// GatedMode returns the gating mode of the repo as "auto", "manual" or "off".
+func (r HubRepo) GatedMode() string {
+	if gated, ok := r.Gated.(string); ok && gated != "" && gated != "false" {
+		return gated
+	}
+	return "off"
+}
+
+type HubCreateRepoRequest struct {
+	Name             string `json:"name"`
+	Organization     string `json:"organization,omitempty"`
+	Type             string `json:"type,omitempty"`
+	Private          bool   `json:"private"`
+	Sdk              string `json:"sdk,omitempty"`
+	Hardware         string `json:"hardware,omitempty"`
+	StorageTier      string `json:"storageTier,omitempty"`
+	SleepTimeSeconds *int64 `json:"sleepTimeSeconds,omitempty"`
+}
+
+type HubCreateRepoResponse struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+type HubRepoSettings struct {
+	Private             *bool       `json:"private,omitempty"`
+	Gated               interface{} `json:"gated,omitempty"`
+	DiscussionsDisabled *bool       `json:"discussionsDisabled,omitempty"`
+}
+
+type HubDeleteRepoRequest struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization,omitempty"`
+	Type         string `json:"type,omitempty"`
+}
+
+type HubRepoRefs struct {
+	Branches []HubGitRef `json:"branches"`
+	Tags     []HubGitRef `json:"tags"`
+}
+
+type HubGitRef struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref"`
+	TargetCommit string `json:"targetCommit"`
+}
+
+type HubCreateBranchRequest struct {
+	StartingPoint string `json:"startingPoint,omitempty"`
+}
+
+type HubCreateTagRequest struct {
+	Tag     string  `json:"tag"`
+	Message *string `json:"message,omitempty"`
+}
+
+type HubAccessRequest struct {
+	User      HubAccessRequestUser   `json:"user"`
+	Status    string                 `json:"status"`
+	Timestamp string                 `json:"timestamp"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+type HubAccessRequestUser struct {
+	User     string `json:"user"`
+	Fullname string `json:"fullname"`
+	Email    string `json:"email,omitempty"`
+}
+
+type HubHandleAccessRequest struct {
+	User   string `json:"user"`
+	Status string `json:"status,omitempty"`
+}
+
+type HubModel struct {
+	ID           string          `json:"id"`
+	Author       string          `json:"author"`
+	Sha          string          `json:"sha"`
+	LastModified string          `json:"lastModified"`
+	Private      bool            `json:"private"`
+	Gated        interface{}     `json:"gated"`
+	PipelineTag  string          `json:"pipeline_tag"`
+	LibraryName  string          `json:"library_name"`
+	Tags         []string        `json:"tags"`
+	Siblings     []HubSibling    `json:"siblings"`
+	Safetensors  *HubSafetensors `json:"safetensors,omitempty"`
+}
+
+type HubSibling struct {
+	RFilename string `json:"rfilename"`
+}
+
+type HubSafetensors struct {
+	Parameters map[string]int64 `json:"parameters"`
+	Total      int64            `json:"total"`
+}
+
+// IsGated reports whether access to the repo requires approval. The Hub
+// returns false for open repos and "auto" or "manual" for gated ones.
+func (m HubModel) IsGated() bool {
+	switch gated := m.Gated.(type) {
+	case bool:
+		return gated
+	case string:
+		return gated != "" && gated != "false"
+	}
+	return false
+}
+
+// Dtype returns the dtype holding the largest share of the model's
+// parameters, which is what the weights are stored as in practice.
+func (s HubSafetensors) Dtype() string {
+	var dtype string
+	var count int64
+	for d, c := range s.Parameters {
+		if c > count || (c == count && d < dtype) {
+			dtype = d
+			count = c
+		}
+	}
+	return dtype
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"net/url"
+	"sort"
+)
+
+// collectionItemTypes are the kinds of Hub objects a collection can hold.
+var collectionItemTypes = []string{"model", "dataset", "space", "paper"}
+
+// collectionThemes are the colors a collection can be displayed with.
+var collectionThemes = []string{"orange", "blue", "green", "purple", "pink", "indigo"}
+
+type HubCollection struct {
+	Slug        string              `json:"slug"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Owner       HubCollectionOwner  `json:"owner"`
+	Private     bool                `json:"private"`
+	Theme       string              `json:"theme"`
+	Items       []HubCollectionItem `json:"items"`
+}
+
+type HubCollectionOwner struct {
+	Name string `json:"name"`
+}
+
+type HubCollectionItem struct {
+	ObjectID string                 `json:"_id"`
+	Type     string                 `json:"type"`
+	ID       string                 `json:"id"`
+	Position int                    `json:"position"`
+	Note     *HubCollectionItemNote `json:"note,omitempty"`
+}
+
+type HubCollectionItemNote struct {
+	Text string `json:"text"`
+}
+
+// NoteText returns the note of the item, or "" if it has none.
+func (i HubCollectionItem) NoteText() string {
+	if i.Note == nil {
+		return ""
+	}
+	return i.Note.Text
+}
+
+// SortedItems returns the items of the collection in display order.
+func (c HubCollection) SortedItems() []HubCollectionItem {
+	items := append([]HubCollectionItem{}, c.Items...)
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items
+}
+
+type HubCreateCollectionRequest struct {
+	Title       string  `json:"title"`
+	Namespace   string  `json:"namespace"`
+	Description *string `json:"description,omitempty"`
+	Private     bool    `json:"private"`
+}
+
+type HubUpdateCollectionRequest struct {
+	Title       *string `json:"title,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Private     *bool   `json:"private,omitempty"`
+	Theme       *string `json:"theme,omitempty"`
+}
+
+type HubAddCollectionItemRequest struct {
+	Item HubCollectionItemRef `json:"item"`
+	Note *string              `json:"note,omitempty"`
+}
+
+type HubCollectionItemRef struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type HubUpdateCollectionItemRequest struct {
+	Note     *string `json:"note,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
+
+func (c *hubClient) GetCollection(slug string) (HubCollection, error) {
+	var collection HubCollection
+	err := c.doJSON("GET", "/api/collections/"+escapeRepoID(slug), nil, &collection, "failed to get collection")
+	if err != nil {
+		return HubCollection{}, err
+	}
+
+	return collection, nil
+}
+
+func (c *hubClient) CreateCollection(request HubCreateCollectionRequest) (HubCollection, error) {
+	var collection HubCollection
+	err := c.doJSON("POST", "/api/collections", request, &collection, "failed to create collection")
+	if err != nil {
+		return HubCollection{}, err
+	}
+
+	return collection, nil
+}
+
+func (c *hubClient) UpdateCollection(slug string, request HubUpdateCollectionRequest) error {
+	return c.doJSON("PATCH", "/api/collections/"+escapeRepoID(slug), request, nil, "failed to update collection")
+}
+
+func (c *hubClient) DeleteCollection(slug string) error {
+	return c.doJSON("DELETE", "/api/collections/"+escapeRepoID(slug), nil, nil, "failed to delete collection")
+}
+
+func (c *hubClient) AddCollectionItem(slug string, request HubAddCollectionItemRequest) error {
+	return c.doJSON("POST", "/api/collections/"+escapeRepoID(slug)+"/item", request, nil, "failed to add collection item")
+}
+
+func (c *hubClient) UpdateCollectionItem(slug string, objectID string, request HubUpdateCollectionItemRequest) error {
+	return c.doJSON("PATCH", "/api/collections/"+escapeRepoID(slug)+"/items/"+url.PathEscape(objectID), request, nil, "failed to update collection item")
+}
+
+func (c *hubClient) DeleteCollectionItem(slug string, objectID string) error {
+	return c.doJSON("DELETE", "/api/collections/"+escapeRepoID(slug)+"/items/"+url.PathEscape(objectID), nil, nil, "failed to delete collection item")
+}
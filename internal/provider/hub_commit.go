@@ -0,0 +1,570 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+// lfsSampleSize is how many leading bytes of a file the preupload API looks
+// at to decide whether it is binary.
+const lfsSampleSize = 512
+
+// hubBatchSize is the most files a single preupload or LFS batch request
+// lists, the same limit the huggingface_hub library uses, so that syncing a
+// large folder doesn't send one unbounded request.
+const hubBatchSize = 256
+
+// HubCommitAdd is a file to add or overwrite in a commit. Its content comes
+// either from memory or from a local file, which is streamed so that large
+// weights never have to fit in memory.
+type HubCommitAdd struct {
+	Path      string
+	Content   []byte
+	LocalPath string
+	Size      int64
+	Sha256    string
+	BlobSha   string
+	Sample    []byte
+
+	uploadMode string
+}
+
+func newCommitAddFromBytes(path string, content []byte) *HubCommitAdd {
+	sum := sha256.Sum256(content)
+	sample := content
+	if len(sample) > lfsSampleSize {
+		sample = sample[:lfsSampleSize]
+	}
+	return &HubCommitAdd{
+		Path:    path,
+		Content: content,
+		Size:    int64(len(content)),
+		Sha256:  hex.EncodeToString(sum[:]),
+		BlobSha: gitBlobSha(content),
+		Sample:  sample,
+	}
+}
+
+func newCommitAddFromFile(path string, localPath string) (*HubCommitAdd, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sample := make([]byte, lfsSampleSize)
+	n, err := io.ReadFull(file, sample)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	blobHash := sha1.New()
+	fmt.Fprintf(blobHash, "blob %d\x00", info.Size())
+	size, err := io.Copy(io.MultiWriter(hash, blobHash), file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HubCommitAdd{
+		Path:      path,
+		LocalPath: localPath,
+		Size:      size,
+		Sha256:    hex.EncodeToString(hash.Sum(nil)),
+		BlobSha:   hex.EncodeToString(blobHash.Sum(nil)),
+		Sample:    sample[:n],
+	}, nil
+}
+
+func (a *HubCommitAdd) open() (io.ReadCloser, error) {
+	if a.LocalPath != "" {
+		return os.Open(a.LocalPath)
+	}
+	return io.NopCloser(bytes.NewReader(a.Content)), nil
+}
+
+func (a *HubCommitAdd) readAll() ([]byte, error) {
+	if a.LocalPath != "" {
+		return os.ReadFile(a.LocalPath)
+	}
+	return a.Content, nil
+}
+
+// gitBlobSha returns the git object id of content, which is how the Hub
+// identifies regular (non-LFS) files.
+func gitBlobSha(content []byte) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00", len(content))
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (c *hubClient) repoAPIPath(repoType string, repoID string) string {
+	return "/api/" + repoTypePath(repoType) + "/" + escapeRepoID(repoID)
+}
+
+// repoGitPath returns the path of a repo's git remote on the Hub, e.g.
+// "/datasets/org/name.git".
+func repoGitPath(repoType string, repoID string) string {
+	if repoType == "model" || repoType == "" {
+		return "/" + escapeRepoID(repoID) + ".git"
+	}
+	return "/" + repoTypePath(repoType) + "/" + escapeRepoID(repoID) + ".git"
+}
+
+type hubPreuploadRequest struct {
+	Files []hubPreuploadFile `json:"files"`
+}
+
+type hubPreuploadFile struct {
+	Path   string `json:"path"`
+	Sample string `json:"sample"`
+	Size   int64  `json:"size"`
+}
+
+type hubPreuploadResponse struct {
+	Files []struct {
+		Path       string `json:"path"`
+		UploadMode string `json:"uploadMode"`
+	} `json:"files"`
+}
+
+// preupload asks the Hub which of the files must go through LFS, based on
+// the repo's .gitattributes and the content sample.
+func (c *hubClient) preupload(repoType string, repoID string, revision string, adds []*HubCommitAdd) error {
+	for start := 0; start < len(adds); start += hubBatchSize {
+		err := c.preuploadBatch(repoType, repoID, revision, adds[start:min(start+hubBatchSize, len(adds))])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *hubClient) preuploadBatch(repoType string, repoID string, revision string, adds []*HubCommitAdd) error {
+	request := hubPreuploadRequest{}
+	for _, add := range adds {
+		request.Files = append(request.Files, hubPreuploadFile{
+			Path:   add.Path,
+			Sample: base64.StdEncoding.EncodeToString(add.Sample),
+			Size:   add.Size,
+		})
+	}
+
+	var response hubPreuploadResponse
+	err := c.doJSON("POST", c.repoAPIPath(repoType, repoID)+"/preupload/"+url.PathEscape(revision), request, &response, "failed to preupload files")
+	if err != nil {
+		return err
+	}
+
+	modes := make(map[string]string, len(response.Files))
+	for _, file := range response.Files {
+		modes[file.Path] = file.UploadMode
+	}
+	for _, add := range adds {
+		add.uploadMode = modes[add.Path]
+	}
+
+	return nil
+}
+
+type hubLFSBatchRequest struct {
+	Operation string            `json:"operation"`
+	Transfers []string          `json:"transfers"`
+	Objects   []hubLFSObject    `json:"objects"`
+	HashAlgo  string            `json:"hash_algo"`
+	Ref       map[string]string `json:"ref,omitempty"`
+}
+
+type hubLFSObject struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+type hubLFSAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header"`
+}
+
+type hubLFSBatchResponse struct {
+	Objects []struct {
+		Oid     string                  `json:"oid"`
+		Size    int64                   `json:"size"`
+		Actions map[string]hubLFSAction `json:"actions"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"objects"`
+}
+
+// uploadLFS pushes the content of LFS files to storage. Objects the Hub
+// already has come back without an upload action and are skipped.
+func (c *hubClient) uploadLFS(repoType string, repoID string, revision string, adds []*HubCommitAdd) error {
+	byOid := make(map[string]*HubCommitAdd)
+	var objects []hubLFSObject
+	for _, add := range adds {
+		if add.uploadMode != "lfs" {
+			continue
+		}
+		if _, ok := byOid[add.Sha256]; ok {
+			continue
+		}
+		byOid[add.Sha256] = add
+		objects = append(objects, hubLFSObject{Oid: add.Sha256, Size: add.Size})
+	}
+
+	for start := 0; start < len(objects); start += hubBatchSize {
+		err := c.uploadLFSBatch(repoType, repoID, revision, objects[start:min(start+hubBatchSize, len(objects))], byOid)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *hubClient) uploadLFSBatch(repoType string, repoID string, revision string, objects []hubLFSObject, byOid map[string]*HubCommitAdd) error {
+	request := hubLFSBatchRequest{
+		Operation: "upload",
+		Transfers: []string{"basic", "multipart"},
+		Objects:   objects,
+		HashAlgo:  "sha256",
+		Ref:       map[string]string{"name": "refs/heads/" + revision},
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("%w: %v", huggingface.ErrEncodingRequestBody, err)
+	}
+	headers := map[string]string{
+		"Accept":       "application/vnd.git-lfs+json",
+		"Content-Type": "application/vnd.git-lfs+json",
+	}
+	respBody, statusCode, err := c.doLongRaw("POST", c.HostURL+repoGitPath(repoType, repoID)+"/info/lfs/objects/batch", bytes.NewReader(body), headers)
+	var response hubLFSBatchResponse
+	err = decodeResponse(respBody, statusCode, err, &response, "failed to request lfs upload")
+	if err != nil {
+		return err
+	}
+
+	for _, object := range response.Objects {
+		if object.Error != nil {
+			return fmt.Errorf("lfs object %s was rejected: %s", object.Oid, object.Error.Message)
+		}
+		add, ok := byOid[object.Oid]
+		if !ok {
+			continue
+		}
+		upload, ok := object.Actions["upload"]
+		if !ok {
+			continue
+		}
+		if _, multipart := upload.Header["chunk_size"]; multipart {
+			err = c.uploadLFSMultipart(add, upload)
+		} else {
+			err = c.uploadLFSSingle(add, upload)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", add.Path, err)
+		}
+		if verify, ok := object.Actions["verify"]; ok {
+			err = c.verifyLFS(add, verify)
+			if err != nil {
+				return fmt.Errorf("failed to verify %s: %w", add.Path, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// doUpload is doRaw without the client timeout, for request bodies that can
+// take a long time to send.
+func (c *hubClient) doUpload(method string, rawURL string, body io.Reader, size int64, headers map[string]string) (http.Header, error) {
+	req, err := http.NewRequest(method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", huggingface.ErrCreatingRequest, err)
+	}
+	req.ContentLength = size
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := c.UploadHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", huggingface.ErrExecutingRequest, err)
+	}
+	defer res.Body.Close()
+
+	respBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", huggingface.ErrReadingResponseBody, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		bodyStr := string(respBody)
+		return nil, &huggingface.HTTPError{
+			StatusCode: res.StatusCode,
+			Body:       &bodyStr,
+			Message:    "failed to upload lfs object",
+		}
+	}
+
+	return res.Header, nil
+}
+
+func (c *hubClient) uploadLFSSingle(add *HubCommitAdd, action hubLFSAction) error {
+	content, err := add.open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	_, err = c.doUpload("PUT", action.Href, content, add.Size, action.Header)
+	return err
+}
+
+// uploadLFSMultipart uploads a file in chunks to the presigned part URLs
+// listed in the action header, then completes the upload with their ETags.
+func (c *hubClient) uploadLFSMultipart(add *HubCommitAdd, action hubLFSAction) error {
+	chunkSize, err := strconv.ParseInt(action.Header["chunk_size"], 10, 64)
+	if err != nil || chunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size %q", action.Header["chunk_size"])
+	}
+
+	var partNumbers []int
+	for key := range action.Header {
+		if partNumber, err := strconv.Atoi(key); err == nil {
+			partNumbers = append(partNumbers, partNumber)
+		}
+	}
+	sort.Ints(partNumbers)
+
+	content, err := add.open()
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+	reader := bufio.NewReader(content)
+
+	type part struct {
+		PartNumber int    `json:"partNumber"`
+		Etag       string `json:"etag"`
+	}
+	var parts []part
+	remaining := add.Size
+	for _, partNumber := range partNumbers {
+		size := chunkSize
+		if remaining < size {
+			size = remaining
+		}
+		headers, err := c.doUpload("PUT", action.Header[strconv.Itoa(partNumber)], io.LimitReader(reader, size), size, nil)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part{PartNumber: partNumber, Etag: headers.Get("ETag")})
+		remaining -= size
+	}
+
+	completion, err := json.Marshal(map[string]interface{}{
+		"oid":   add.Sha256,
+		"parts": parts,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", huggingface.ErrEncodingRequestBody, err)
+	}
+	respBody, statusCode, err := c.doLongRaw("POST", action.Href, bytes.NewReader(completion), map[string]string{
+		"Accept":       "application/vnd.git-lfs+json",
+		"Content-Type": "application/vnd.git-lfs+json",
+	})
+	return decodeResponse(respBody, statusCode, err, nil, "failed to complete multipart upload")
+}
+
+func (c *hubClient) verifyLFS(add *HubCommitAdd, action hubLFSAction) error {
+	body, err := json.Marshal(hubLFSObject{Oid: add.Sha256, Size: add.Size})
+	if err != nil {
+		return fmt.Errorf("%w: %v", huggingface.ErrEncodingRequestBody, err)
+	}
+	headers := map[string]string{
+		"Accept":       "application/vnd.git-lfs+json",
+		"Content-Type": "application/vnd.git-lfs+json",
+	}
+	for key, value := range action.Header {
+		headers[key] = value
+	}
+	respBody, statusCode, err := c.doLongRaw("POST", action.Href, bytes.NewReader(body), headers)
+	return decodeResponse(respBody, statusCode, err, nil, "failed to verify lfs object")
+}
+
+type HubCommitResponse struct {
+	CommitURL string `json:"commitUrl"`
+	CommitOid string `json:"commitOid"`
+}
+
+type hubCommitLine struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// CreateCommit adds and deletes files on a branch in a single commit. LFS
+// content is uploaded first; regular files are inlined in the commit.
+func (c *hubClient) CreateCommit(repoType string, repoID string, revision string, summary string, adds []*HubCommitAdd, deletes []string) (HubCommitResponse, error) {
+	if len(adds) > 0 {
+		err := c.preupload(repoType, repoID, revision, adds)
+		if err != nil {
+			return HubCommitResponse{}, err
+		}
+		err = c.uploadLFS(repoType, repoID, revision, adds)
+		if err != nil {
+			return HubCommitResponse{}, err
+		}
+	}
+
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	lines := []hubCommitLine{{Key: "header", Value: map[string]string{"summary": summary, "description": ""}}}
+	for _, add := range adds {
+		if add.uploadMode == "lfs" {
+			lines = append(lines, hubCommitLine{Key: "lfsFile", Value: map[string]interface{}{
+				"path": add.Path,
+				"algo": "sha256",
+				"oid":  add.Sha256,
+				"size": add.Size,
+			}})
+			continue
+		}
+		content, err := add.readAll()
+		if err != nil {
+			return HubCommitResponse{}, err
+		}
+		lines = append(lines, hubCommitLine{Key: "file", Value: map[string]string{
+			"path":     add.Path,
+			"content":  base64.StdEncoding.EncodeToString(content),
+			"encoding": "base64",
+		}})
+	}
+	for _, path := range deletes {
+		lines = append(lines, hubCommitLine{Key: "deletedFile", Value: map[string]string{"path": path}})
+	}
+	for _, line := range lines {
+		if err := encoder.Encode(line); err != nil {
+			return HubCommitResponse{}, fmt.Errorf("%w: %v", huggingface.ErrEncodingRequestBody, err)
+		}
+	}
+
+	respBody, statusCode, err := c.doLongRaw("POST", c.HostURL+c.repoAPIPath(repoType, repoID)+"/commit/"+url.PathEscape(revision), &body, map[string]string{
+		"Content-Type": "application/x-ndjson",
+	})
+	var response HubCommitResponse
+	err = decodeResponse(respBody, statusCode, err, &response, "failed to create commit")
+	if err != nil {
+		return HubCommitResponse{}, err
+	}
+
+	return response, nil
+}
+
+type HubPathInfo struct {
+	Type string          `json:"type"`
+	Path string          `json:"path"`
+	Oid  string          `json:"oid"`
+	Size int64           `json:"size"`
+	Lfs  *HubPathInfoLfs `json:"lfs,omitempty"`
+}
+
+type HubPathInfoLfs struct {
+	Oid  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// GetPathsInfo returns the entries found at the given paths on a revision.
+// Paths that don't exist are left out of the result.
+func (c *hubClient) GetPathsInfo(repoType string, repoID string, revision string, paths []string) ([]HubPathInfo, error) {
+	form := url.Values{}
+	for _, path := range paths {
+		form.Add("paths", path)
+	}
+
+	respBody, statusCode, err := c.doRaw("POST", c.HostURL+c.repoAPIPath(repoType, repoID)+"/paths-info/"+url.PathEscape(revision), strings.NewReader(form.Encode()), map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	})
+	var infos []HubPathInfo
+	err = decodeResponse(respBody, statusCode, err, &infos, "failed to get paths info")
+	if err != nil {
+		return nil, err
+	}
+
+	return infos, nil
+}
+
+// Matches reports whether the remote entry holds the same content as add.
+// LFS entries are identified by the sha256 of their content, regular files
+// by their git blob id.
+func (info HubPathInfo) Matches(add *HubCommitAdd) bool {
+	if info.Lfs != nil {
+		return info.Lfs.Oid == add.Sha256
+	}
+	return info.Oid == add.BlobSha
+}
+
+// GetRepoTree lists the files and directories below dir on a revision,
+// recursively. An empty dir lists the whole repository.
+func (c *hubClient) GetRepoTree(repoType string, repoID string, revision string, dir string) ([]HubPathInfo, error) {
+	next := c.HostURL + c.repoAPIPath(repoType, repoID) + "/tree/" + url.PathEscape(revision)
+	if dir != "" {
+		next += "/" + escapeRepoID(dir)
+	}
+	next += "?recursive=true"
+
+	var entries []HubPathInfo
+	for next != "" {
+		respBody, statusCode, header, err := c.doRawWithHeader("GET", next, nil, nil)
+		var page []HubPathInfo
+		err = decodeResponse(respBody, statusCode, err, &page, "failed to list repository tree")
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, page...)
+		next = nextPageURL(header)
+	}
+
+	return entries, nil
+}
+
+// nextPageURL extracts the rel="next" target of a Link header, or returns ""
+// on the last page.
+func nextPageURL(header http.Header) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	tests := map[string]struct {
+		link string
+		want string
+	}{
+		"next page": {
+			link: `<https://huggingface.co/api/models/acme/a/tree/main?recursive=true&cursor=abc>; rel="next"`,
+			want: "https://huggingface.co/api/models/acme/a/tree/main?recursive=true&cursor=abc",
+		},
+		"next among other links": {
+			link: `<https://huggingface.co/page/1>; rel="prev", <https://huggingface.co/page/3>; rel="next"`,
+			want: "https://huggingface.co/page/3",
+		},
+		"next with other parameters": {
+			link: `<https://huggingface.co/page/2>; title="more"; rel="next"`,
+			want: "https://huggingface.co/page/2",
+		},
+		"last page": {
+			link: `<https://huggingface.co/page/1>; rel="prev"`,
+		},
+		"no link header": {},
+		"link without parameters": {
+			link: `<https://huggingface.co/page/2>`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			if test.link != "" {
+				header.Set("Link", test.link)
+			}
+			if got := nextPageURL(header); got != test.want {
+				t.Errorf("nextPageURL(%q) = %q, want %q", test.link, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"net/url"
+)
+
+// organizationRoles are the roles a member can have in an organization or a
+// resource group, from least to most privileged.
+var organizationRoles = []string{"read", "contributor", "write", "admin"}
+
+type HubOrganization struct {
+	Name     string `json:"name"`
+	Fullname string `json:"fullname"`
+	Plan     string `json:"plan,omitempty"`
+}
+
+type HubOrganizationMember struct {
+	User     string `json:"user"`
+	Fullname string `json:"fullname"`
+	Role     string `json:"role"`
+}
+
+type HubOrganizationMemberRequest struct {
+	User string `json:"user,omitempty"`
+	Role string `json:"role"`
+}
+
+func (c *hubClient) GetOrganization(name string) (HubOrganization, error) {
+	var organization HubOrganization
+	err := c.doJSON("GET", "/api/organizations/"+url.PathEscape(name)+"/overview", nil, &organization, "failed to get organization")
+	if err != nil {
+		return HubOrganization{}, err
+	}
+
+	return organization, nil
+}
+
+// GetOrganizationMembers lists the members of an organization, following the
+// pagination links of large organizations. Roles are only reported to
+// organization admins.
+func (c *hubClient) GetOrganizationMembers(name string) ([]HubOrganizationMember, error) {
+	next := c.HostURL + "/api/organizations/" + url.PathEscape(name) + "/members"
+
+	var members []HubOrganizationMember
+	for next != "" {
+		respBody, statusCode, header, err := c.doRawWithHeader("GET", next, nil, nil)
+		var page []HubOrganizationMember
+		err = decodeResponse(respBody, statusCode, err, &page, "failed to list organization members")
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, page...)
+		next = nextPageURL(header)
+	}
+
+	return members, nil
+}
+
+func (c *hubClient) AddOrganizationMember(name string, member HubOrganizationMemberRequest) error {
+	return c.doJSON("POST", "/api/organizations/"+url.PathEscape(name)+"/members", member, nil, "failed to add organization member")
+}
+
+func (c *hubClient) SetOrganizationMemberRole(name string, user string, role string) error {
+	request := HubOrganizationMemberRequest{Role: role}
+	return c.doJSON("PUT", "/api/organizations/"+url.PathEscape(name)+"/members/"+url.PathEscape(user)+"/role", request, nil, "failed to change organization member role")
+}
+
+func (c *hubClient) RemoveOrganizationMember(name string, user string) error {
+	return c.doJSON("DELETE", "/api/organizations/"+url.PathEscape(name)+"/members/"+url.PathEscape(user), nil, nil, "failed to remove organization member")
+}
+
+func findOrganizationMember(members []HubOrganizationMember, user string) (HubOrganizationMember, bool) {
+	for _, member := range members {
+		if member.User == user {
+			return member, true
+		}
+	}
+	return HubOrganizationMember{}, false
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"net/url"
+)
+
+type HubResourceGroup struct {
+	ID          string                   `json:"id"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Users       []HubResourceGroupMember `json:"users"`
+}
+
+type HubResourceGroupMember struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
+
+type HubResourceGroupRequest struct {
+	Name        *string                  `json:"name,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	Users       []HubResourceGroupMember `json:"users,omitempty"`
+}
+
+type HubResourceGroupRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type hubRepoResourceGroupRequest struct {
+	ResourceGroupID *string `json:"resourceGroupId"`
+}
+
+func (c *hubClient) resourceGroupPath(organization string, id string) string {
+	path := "/api/organizations/" + url.PathEscape(organization) + "/resource-groups"
+	if id != "" {
+		path += "/" + url.PathEscape(id)
+	}
+	return path
+}
+
+func (c *hubClient) GetResourceGroup(organization string, id string) (HubResourceGroup, error) {
+	var group HubResourceGroup
+	err := c.doJSON("GET", c.resourceGroupPath(organization, id), nil, &group, "failed to get resource group")
+	if err != nil {
+		return HubResourceGroup{}, err
+	}
+
+	return group, nil
+}
+
+func (c *hubClient) CreateResourceGroup(organization string, request HubResourceGroupRequest) (HubResourceGroup, error) {
+	var group HubResourceGroup
+	err := c.doJSON("POST", c.resourceGroupPath(organization, ""), request, &group, "failed to create resource group")
+	if err != nil {
+		return HubResourceGroup{}, err
+	}
+
+	return group, nil
+}
+
+func (c *hubClient) UpdateResourceGroup(organization string, id string, request HubResourceGroupRequest) error {
+	return c.doJSON("PATCH", c.resourceGroupPath(organization, id), request, nil, "failed to update resource group")
+}
+
+func (c *hubClient) DeleteResourceGroup(organization string, id string) error {
+	return c.doJSON("DELETE", c.resourceGroupPath(organization, id), nil, nil, "failed to delete resource group")
+}
+
+func (c *hubClient) AddResourceGroupMembers(organization string, id string, members []HubResourceGroupMember) error {
+	request := HubResourceGroupRequest{Users: members}
+	return c.doJSON("POST", c.resourceGroupPath(organization, id)+"/users", request, nil, "failed to add resource group members")
+}
+
+func (c *hubClient) SetResourceGroupMemberRole(organization string, id string, user string, role string) error {
+	request := HubResourceGroupMember{Role: role}
+	return c.doJSON("PATCH", c.resourceGroupPath(organization, id)+"/users/"+url.PathEscape(user), request, nil, "failed to change resource group member role")
+}
+
+func (c *hubClient) RemoveResourceGroupMember(organization string, id string, user string) error {
+	return c.doJSON("DELETE", c.resourceGroupPath(organization, id)+"/users/"+url.PathEscape(user), nil, nil, "failed to remove resource group member")
+}
+
+// GetRepoResourceGroup returns the resource group a repo belongs to, or nil
+// if it isn't in one.
+func (c *hubClient) GetRepoResourceGroup(repoType string, repoID string) (*HubResourceGroupRef, error) {
+	var repo HubRepo
+	err := c.doJSON("GET", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"?expand[]=resourceGroup", nil, &repo, "failed to get repository")
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.ResourceGroup, nil
+}
+
+// SetRepoResourceGroup moves a repo into a resource group, or out of any
+// group when resourceGroupID is nil.
+func (c *hubClient) SetRepoResourceGroup(repoType string, repoID string, resourceGroupID *string) error {
+	request := hubRepoResourceGroupRequest{ResourceGroupID: resourceGroupID}
+	return c.doJSON("POST", "/api/"+repoTypePath(repoType)+"/"+escapeRepoID(repoID)+"/resource-group", request, nil, "failed to set repository resource group")
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"net/url"
+)
+
+// webhookWatchedTypes are the kinds of Hub objects a webhook can watch.
+var webhookWatchedTypes = []string{"user", "org", "model", "dataset", "space"}
+
+// webhookDomains are the kinds of events a webhook can be sent for.
+var webhookDomains = []string{"repo", "discussion"}
+
+type HubWebhook struct {
+	ID       string              `json:"id"`
+	URL      string              `json:"url"`
+	Watched  []HubWebhookWatched `json:"watched"`
+	Domains  []string            `json:"domains"`
+	Secret   *string             `json:"secret,omitempty"`
+	Disabled bool                `json:"disabled"`
+}
+
+type HubWebhookWatched struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type HubWebhookRequest struct {
+	URL     string              `json:"url"`
+	Watched []HubWebhookWatched `json:"watched"`
+	Domains []string            `json:"domains"`
+	Secret  *string             `json:"secret,omitempty"`
+}
+
+type hubWebhookResponse struct {
+	Webhook HubWebhook `json:"webhook"`
+}
+
+func (c *hubClient) GetWebhook(id string) (HubWebhook, error) {
+	var response hubWebhookResponse
+	err := c.doJSON("GET", "/api/settings/webhooks/"+url.PathEscape(id), nil, &response, "failed to get webhook")
+	if err != nil {
+		return HubWebhook{}, err
+	}
+
+	return response.Webhook, nil
+}
+
+func (c *hubClient) CreateWebhook(request HubWebhookRequest) (HubWebhook, error) {
+	var response hubWebhookResponse
+	err := c.doJSON("POST", "/api/settings/webhooks", request, &response, "failed to create webhook")
+	if err != nil {
+		return HubWebhook{}, err
+	}
+
+	return response.Webhook, nil
+}
+
+func (c *hubClient) UpdateWebhook(id string, request HubWebhookRequest) (HubWebhook, error) {
+	var response hubWebhookResponse
+	err := c.doJSON("POST", "/api/settings/webhooks/"+url.PathEscape(id), request, &response, "failed to update webhook")
+	if err != nil {
+		return HubWebhook{}, err
+	}
+
+	return response.Webhook, nil
+}
+
+// SetWebhookDisabled pauses or resumes the delivery of events to a webhook.
+func (c *hubClient) SetWebhookDisabled(id string, disabled bool) error {
+	action := "enable"
+	if disabled {
+		action = "disable"
+	}
+	return c.doJSON("POST", "/api/settings/webhooks/"+url.PathEscape(id)+"/"+action, nil, nil, "failed to "+action+" webhook")
+}
+
+func (c *hubClient) DeleteWebhook(id string) error {
+	return c.doJSON("DELETE", "/api/settings/webhooks/"+url.PathEscape(id), nil, nil, "failed to delete webhook")
+}
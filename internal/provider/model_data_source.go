@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ datasource.DataSource              = &modelDataSource{}
+	_ datasource.DataSourceWithConfigure = &modelDataSource{}
+)
+
+func NewModelDataSource() datasource.DataSource {
+	return &modelDataSource{}
+}
+
+type modelDataSource struct {
+	hub *hubClient
+}
+
+type modelDataSourceModel struct {
+	Repository   types.String      `tfsdk:"repository"`
+	Revision     types.String      `tfsdk:"revision"`
+	ID           types.String      `tfsdk:"id"`
+	Author       types.String      `tfsdk:"author"`
+	Sha          types.String      `tfsdk:"sha"`
+	LastModified types.String      `tfsdk:"last_modified"`
+	Private      types.Bool        `tfsdk:"private"`
+	Gated        types.Bool        `tfsdk:"gated"`
+	PipelineTag  types.String      `tfsdk:"pipeline_tag"`
+	LibraryName  types.String      `tfsdk:"library_name"`
+	Framework    types.String      `tfsdk:"framework"`
+	Tags         []string          `tfsdk:"tags"`
+	Siblings     []string          `tfsdk:"siblings"`
+	Safetensors  *ModelSafetensors `tfsdk:"safetensors"`
+}
+
+type ModelSafetensors struct {
+	Total      int64            `tfsdk:"total"`
+	Dtype      string           `tfsdk:"dtype"`
+	Parameters map[string]int64 `tfsdk:"parameters"`
+}
+
+func (d *modelDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.hub = data.hub
+}
+
+func (d *modelDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_model"
+}
+
+func (d *modelDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the metadata of a model repository from the Hugging Face Hub.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				MarkdownDescription: "The model repository ID, e.g. \"org/model\".",
+				Required:            true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit to read. Defaults to the main branch.",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID as reported by the Hub.",
+				Computed:            true,
+			},
+			"author": schema.StringAttribute{
+				MarkdownDescription: "The repository owner.",
+				Computed:            true,
+			},
+			"sha": schema.StringAttribute{
+				MarkdownDescription: "The commit the metadata was read at.",
+				Computed:            true,
+			},
+			"last_modified": schema.StringAttribute{
+				MarkdownDescription: "The time of the last commit.",
+				Computed:            true,
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the repository is private.",
+				Computed:            true,
+			},
+			"gated": schema.BoolAttribute{
+				MarkdownDescription: "Whether access to the repository requires approval.",
+				Computed:            true,
+			},
+			"pipeline_tag": schema.StringAttribute{
+				MarkdownDescription: "The task the model is tagged with on the Hub.",
+				Computed:            true,
+			},
+			"library_name": schema.StringAttribute{
+				MarkdownDescription: "The library the model is tagged with on the Hub.",
+				Computed:            true,
+			},
+			"framework": schema.StringAttribute{
+				MarkdownDescription: "The endpoint framework matching the model's weights: \"pytorch\", \"tensorflow\" or \"custom\".",
+				Computed:            true,
+			},
+			"tags": schema.ListAttribute{
+				MarkdownDescription: "The repository tags.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"siblings": schema.ListAttribute{
+				MarkdownDescription: "The names of the files in the repository.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"safetensors": schema.SingleNestedAttribute{
+				MarkdownDescription: "The safetensors metadata, when available.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"total": schema.Int64Attribute{
+						MarkdownDescription: "The total parameter count.",
+						Computed:            true,
+					},
+					"dtype": schema.StringAttribute{
+						MarkdownDescription: "The dtype holding most of the parameters.",
+						Computed:            true,
+					},
+					"parameters": schema.MapAttribute{
+						MarkdownDescription: "The parameter count per dtype.",
+						Computed:            true,
+						ElementType:         types.Int64Type,
+					},
+				},
+			},
+		},
+	}
+}
+
+// hubModelFrameworks lists the inference endpoints frameworks the model ships
+// weights for, based on its Hub tags.
+func hubModelFrameworks(model HubModel) []string {
+	tags := make(map[string]bool, len(model.Tags))
+	for _, tag := range model.Tags {
+		tags[tag] = true
+	}
+	var frameworks []string
+	if tags["pytorch"] || tags["safetensors"] {
+		frameworks = append(frameworks, "pytorch")
+	}
+	if tags["tf"] || tags["keras"] {
+		frameworks = append(frameworks, "tensorflow")
+	}
+	return frameworks
+}
+
+// hubModelFramework picks the framework an endpoint should use for the model,
+// preferring pytorch and falling back to "custom" when the weights are in a
+// format the default containers don't load.
+func hubModelFramework(model HubModel) string {
+	frameworks := hubModelFrameworks(model)
+	if len(frameworks) == 0 {
+		return "custom"
+	}
+	return frameworks[0]
+}
+
+func hubModelToModelDataSource(model HubModel, revision types.String) modelDataSourceModel {
+	siblings := make([]string, 0, len(model.Siblings))
+	for _, sibling := range model.Siblings {
+		siblings = append(siblings, sibling.RFilename)
+	}
+
+	tags := model.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	var safetensors *ModelSafetensors = nil
+	if model.Safetensors != nil {
+		safetensors = &ModelSafetensors{
+			Total:      model.Safetensors.Total,
+			Dtype:      model.Safetensors.Dtype(),
+			Parameters: model.Safetensors.Parameters,
+		}
+		if safetensors.Parameters == nil {
+			safetensors.Parameters = make(map[string]int64)
+		}
+	}
+
+	return modelDataSourceModel{
+		Repository:   types.StringValue(model.ID),
+		Revision:     revision,
+		ID:           types.StringValue(model.ID),
+		Author:       types.StringValue(model.Author),
+		Sha:          types.StringValue(model.Sha),
+		LastModified: types.StringValue(model.LastModified),
+		Private:      types.BoolValue(model.Private),
+		Gated:        types.BoolValue(model.IsGated()),
+		PipelineTag:  types.StringValue(model.PipelineTag),
+		LibraryName:  types.StringValue(model.LibraryName),
+		Framework:    types.StringValue(hubModelFramework(model)),
+		Tags:         tags,
+		Siblings:     siblings,
+		Safetensors:  safetensors,
+	}
+}
+
+func (d *modelDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config modelDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, err := d.hub.GetModel(config.Repository.ValueString(), config.Revision.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"model not found",
+				"could not find model repository "+config.Repository.ValueString()+" on the hub",
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"error reading model",
+			"could not read model repository "+config.Repository.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state := hubModelToModelDataSource(model, config.Revision)
+	// Required attributes must match the configuration, even when the hub
+	// reports the repo id with a different casing or after a rename.
+	state.Repository = config.Repository
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
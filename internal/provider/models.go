@@ -94,9 +94,19 @@ type Tgi struct {
 	MaxInputLength        *int        `tfsdk:"max_input_length"`
 	MaxTotalTokens        *int        `tfsdk:"max_total_tokens"`
 	DisableCustomKernels  *bool       `tfsdk:"disable_custom_kernels"`
+	DiableCustomKernels   *bool       `tfsdk:"diable_custom_kernels"`
 	Quantize              *string     `tfsdk:"quantize"`
 }
 
+// disableCustomKernels is the configured disable_custom_kernels, falling back
+// to its deprecated misspelling diable_custom_kernels.
+func (t *Tgi) disableCustomKernels() *bool {
+	if t.DisableCustomKernels != nil {
+		return t.DisableCustomKernels
+	}
+	return t.DiableCustomKernels
+}
+
 type Custom struct {
 	Credentials *Credentials `tfsdk:"credentials"`
 	HealthRoute *string      `tfsdk:"health_route"`
@@ -139,6 +149,11 @@ type Private struct {
 	ServiceName string `tfsdk:"service_name"`
 }
 
+type PrivateService struct {
+	AccountId string `tfsdk:"account_id"`
+	Shared    bool   `tfsdk:"shared"`
+}
+
 type Vllm struct {
 	HealthRoute          *string     `tfsdk:"health_route"`
 	Port                 types.Int64 `tfsdk:"port"`
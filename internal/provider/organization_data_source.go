@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ datasource.DataSource              = &organizationDataSource{}
+	_ datasource.DataSourceWithConfigure = &organizationDataSource{}
+)
+
+func NewOrganizationDataSource() datasource.DataSource {
+	return &organizationDataSource{}
+}
+
+type organizationDataSource struct {
+	hub *hubClient
+}
+
+type organizationDataSourceModel struct {
+	Name     types.String         `tfsdk:"name"`
+	Fullname types.String         `tfsdk:"fullname"`
+	Members  []OrganizationMember `tfsdk:"members"`
+}
+
+type OrganizationMember struct {
+	User     string `tfsdk:"user"`
+	Fullname string `tfsdk:"fullname"`
+	Role     string `tfsdk:"role"`
+}
+
+func (d *organizationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.hub = data.hub
+}
+
+func (d *organizationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization"
+}
+
+func (d *organizationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads an organization and its members.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The organization name.",
+				Required:            true,
+			},
+			"fullname": schema.StringAttribute{
+				MarkdownDescription: "The display name of the organization.",
+				Computed:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "The members of the organization.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The username.",
+							Computed:            true,
+						},
+						"fullname": schema.StringAttribute{
+							MarkdownDescription: "The user's full name.",
+							Computed:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The member's role. Only reported when the token belongs to an organization admin.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *organizationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config organizationDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := config.Name.ValueString()
+	organization, err := d.hub.GetOrganization(name)
+	if err == nil {
+		var members []HubOrganizationMember
+		members, err = d.hub.GetOrganizationMembers(name)
+		if err == nil {
+			config.Members = make([]OrganizationMember, 0, len(members))
+			for _, member := range members {
+				config.Members = append(config.Members, OrganizationMember{
+					User:     member.User,
+					Fullname: member.Fullname,
+					Role:     member.Role,
+				})
+			}
+		}
+	}
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"organization not found",
+				"could not find organization "+name+" on the hub",
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"error reading organization",
+			"could not read organization "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	config.Fullname = types.StringValue(organization.Fullname)
+
+	diags = resp.State.Set(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &organizationMemberResource{}
+	_ resource.ResourceWithConfigure   = &organizationMemberResource{}
+	_ resource.ResourceWithImportState = &organizationMemberResource{}
+)
+
+func NewOrganizationMemberResource() resource.Resource {
+	return &organizationMemberResource{}
+}
+
+type organizationMemberResource struct {
+	hub *hubClient
+}
+
+type organizationMemberResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Organization types.String `tfsdk:"organization"`
+	User         types.String `tfsdk:"user"`
+	Role         types.String `tfsdk:"role"`
+}
+
+func (r *organizationMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *organizationMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organization_member"
+}
+
+func (r *organizationMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a user's membership and role in an organization. A user who is already a member is adopted and given the configured role. Destroying the resource removes the user from the organization.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The membership ID (\"organization/user\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "The organization name. Changing it replaces the membership.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The username. Changing it replaces the membership.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				MarkdownDescription: "The role: \"read\", \"contributor\", \"write\" or \"admin\".",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(organizationRoles...),
+				},
+			},
+		},
+	}
+}
+
+func (r *organizationMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan organizationMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization := plan.Organization.ValueString()
+	user := plan.User.ValueString()
+
+	// Adopt users who are already members rather than failing, so that
+	// existing members can be brought under management by role.
+	members, err := r.hub.GetOrganizationMembers(organization)
+	if err == nil {
+		if _, ok := findOrganizationMember(members, user); ok {
+			err = r.hub.SetOrganizationMemberRole(organization, user, plan.Role.ValueString())
+		} else {
+			err = r.hub.AddOrganizationMember(organization, HubOrganizationMemberRequest{
+				User: user,
+				Role: plan.Role.ValueString(),
+			})
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error adding organization member",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(organization + "/" + user)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *organizationMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state organizationMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	members, err := r.hub.GetOrganizationMembers(state.Organization.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading organization member",
+				"could not read members of "+state.Organization.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	member, ok := findOrganizationMember(members, state.User.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if member.Role != "" {
+		state.Role = types.StringValue(member.Role)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *organizationMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan organizationMemberResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.SetOrganizationMemberRole(plan.Organization.ValueString(), plan.User.ValueString(), plan.Role.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating organization member",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *organizationMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state organizationMemberResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.RemoveOrganizationMember(state.Organization.ValueString(), state.User.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error removing organization member",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *organizationMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organization, user, err := splitRepoID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"invalid import id",
+			fmt.Sprintf("expected an import id of the form organization/user, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+}
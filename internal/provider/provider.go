@@ -42,6 +42,9 @@ func (p *huggingfaceProvider) Schema(_ context.Context, _ provider.SchemaRequest
 			"host": schema.StringAttribute{
 				Optional: true,
 			},
+			"hub_host": schema.StringAttribute{
+				Optional: true,
+			},
 			"namespace": schema.StringAttribute{
 				Optional: true,
 			},
@@ -53,8 +56,16 @@ func (p *huggingfaceProvider) Schema(_ context.Context, _ provider.SchemaRequest
 	}
 }
 
+// providerData is handed to every resource and data source. The endpoints
+// API and the Hub API are separate services that share the same token.
+type providerData struct {
+	client *huggingface.Client
+	hub    *hubClient
+}
+
 type huggingfaceProviderModel struct {
 	Host      types.String `tfsdk:"host"`
+	HubHost   types.String `tfsdk:"hub_host"`
 	Namespace types.String `tfsdk:"namespace"`
 	Token     types.String `tfsdk:"token"`
 }
@@ -90,10 +101,15 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 	}
 
 	host := config.Host.ValueString()
+	hubHost := HubHostURL
+	if !config.HubHost.IsNull() && !config.HubHost.IsUnknown() && config.HubHost.ValueString() != "" {
+		hubHost = config.HubHost.ValueString()
+	}
 	namespace := config.Namespace.ValueString()
 	token := config.Token.ValueString()
 
 	ctx = tflog.SetField(ctx, "huggingface_host", host)
+	ctx = tflog.SetField(ctx, "huggingface_hub_host", hubHost)
 	ctx = tflog.SetField(ctx, "huggingface_namespace", namespace)
 	ctx = tflog.SetField(ctx, "huggingface_token", token)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "huggingface_token")
@@ -108,18 +124,42 @@ func (p *huggingfaceProvider) Configure(ctx context.Context, req provider.Config
 		return
 	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	data := &providerData{
+		client: client,
+		hub:    newHubClient(hubHost, namespace, token),
+	}
+
+	resp.DataSourceData = data
+	resp.ResourceData = data
 
 	tflog.Info(ctx, "huggingface provider configured", map[string]any{"success": true})
 }
 
 func (p *huggingfaceProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewModelDataSource,
+		NewEndpointRecommendationDataSource,
+		NewRepoAccessRequestsDataSource,
+		NewOrganizationDataSource,
+	}
 }
 
 func (p *huggingfaceProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewEndpointResource,
+		NewRepositoryResource,
+		NewSpaceResource,
+		NewSpaceSecretResource,
+		NewSpaceVariableResource,
+		NewRepoFileResource,
+		NewRepoFolderResource,
+		NewRepoBranchResource,
+		NewRepoTagResource,
+		NewRepoAccessGrantResource,
+		NewCollectionResource,
+		NewWebhookResource,
+		NewOrganizationMemberResource,
+		NewResourceGroupResource,
+		NewResourceGroupRepositoryResource,
 	}
 }
@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ datasource.DataSource              = &endpointRecommendationDataSource{}
+	_ datasource.DataSourceWithConfigure = &endpointRecommendationDataSource{}
+)
+
+// Recommended images are pinned to releases known to work on inference
+// endpoints, so that a recommendation doesn't change when a new release of
+// a container is published. TEI ships separate images for CPU and GPU.
+const (
+	teiCPUImageURL = "ghcr.io/huggingface/text-embeddings-inference:cpu-1.8"
+	teiGPUImageURL = "ghcr.io/huggingface/text-embeddings-inference:1.8"
+	tgiImageURL    = "ghcr.io/huggingface/text-generation-inference:3.3.5"
+	vllmImageURL   = "vllm/vllm-openai:v0.10.2"
+)
+
+// defaultScaleToZeroTimeout is the idle time in minutes after which
+// recommended endpoints scale down to zero replicas.
+const defaultScaleToZeroTimeout = 15
+
+func NewEndpointRecommendationDataSource() datasource.DataSource {
+	return &endpointRecommendationDataSource{}
+}
+
+type endpointRecommendationDataSource struct {
+	client *huggingface.Client
+	hub    *hubClient
+}
+
+type endpointRecommendationDataSourceModel struct {
+	Repository types.String        `tfsdk:"repository"`
+	Revision   types.String        `tfsdk:"revision"`
+	Vendor     types.String        `tfsdk:"vendor"`
+	Image      types.String        `tfsdk:"image"`
+	Compute    Compute             `tfsdk:"compute"`
+	Model      RecommendationModel `tfsdk:"model"`
+}
+
+// RecommendationModel mirrors the endpoint model block, restricted to the
+// images a recommendation can pick.
+type RecommendationModel struct {
+	Framework  string              `tfsdk:"framework"`
+	Image      RecommendationImage `tfsdk:"image"`
+	Repository string              `tfsdk:"repository"`
+	Revision   string              `tfsdk:"revision"`
+	Task       string              `tfsdk:"task"`
+	Env        map[string]string   `tfsdk:"env"`
+}
+
+type RecommendationImage struct {
+	Huggingface *Huggingface `tfsdk:"huggingface"`
+	Tei         *Tei         `tfsdk:"tei"`
+	Tgi         *Tgi         `tfsdk:"tgi"`
+	Vllm        *Vllm        `tfsdk:"vllm"`
+}
+
+func (d *endpointRecommendationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.client = data.client
+	d.hub = data.hub
+}
+
+func (d *endpointRecommendationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_endpoint_recommendation"
+}
+
+func (d *endpointRecommendationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Builds a ready-to-deploy `compute` and `model` configuration for a Hub model.\n\nThe image is chosen from the model's pipeline tag: TEI for embeddings and rerankers, TGI for text generation, the default container otherwise. The instance comes from the inference endpoints catalog, restricted to the instances available for `vendor`: the GPU instance with the least accelerator memory that fits the model's estimated footprint, or for small encoder models the CPU instance with the least RAM that fits it. Images are pinned releases rather than `latest`: `ghcr.io/huggingface/text-embeddings-inference:1.8` (`cpu-1.8` on CPU instances), `ghcr.io/huggingface/text-generation-inference:3.3.5` and `vllm/vllm-openai:v0.10.2`.",
+		Attributes: map[string]schema.Attribute{
+			"repository": schema.StringAttribute{
+				MarkdownDescription: "The model repository ID.",
+				Required:            true,
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit to read. The recommendation pins `model.revision` to the resolved commit.",
+				Optional:            true,
+			},
+			"vendor": schema.StringAttribute{
+				MarkdownDescription: "The cloud vendor to pick an instance for. Defaults to \"aws\".",
+				Optional:            true,
+				Computed:            true,
+			},
+			"image": schema.StringAttribute{
+				MarkdownDescription: "Forces the image: \"huggingface\", \"tei\", \"tgi\" or \"vllm\".",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("huggingface", "tei", "tgi", "vllm"),
+				},
+			},
+			"compute": schema.SingleNestedAttribute{
+				MarkdownDescription: "The recommended `compute` block, scaling between 0 and 1 replica.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"accelerator": schema.StringAttribute{
+						Computed: true,
+					},
+					"instance_size": schema.StringAttribute{
+						Computed: true,
+					},
+					"instance_type": schema.StringAttribute{
+						Computed: true,
+					},
+					"scaling": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"max_replica": schema.Int64Attribute{
+								Computed: true,
+							},
+							"min_replica": schema.Int64Attribute{
+								Computed: true,
+							},
+							"scale_to_zero_timeout": schema.Int64Attribute{
+								Computed: true,
+							},
+							"measure": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"hardware_usage": schema.Float64Attribute{
+										Computed: true,
+									},
+									"pending_requests": schema.Float64Attribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"model": schema.SingleNestedAttribute{
+				MarkdownDescription: "The recommended `model` block, including the image.",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"framework": schema.StringAttribute{
+						Computed: true,
+					},
+					"env": schema.MapAttribute{
+						Computed:    true,
+						ElementType: types.StringType,
+					},
+					"image": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"huggingface": schema.SingleNestedAttribute{
+								Computed: true,
+							},
+							"tei": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"health_route": schema.StringAttribute{
+										Computed: true,
+									},
+									"port": schema.Int64Attribute{
+										Computed: true,
+									},
+									"url": schema.StringAttribute{
+										Computed: true,
+									},
+									"max_batch_tokens": schema.Int64Attribute{
+										Computed: true,
+									},
+									"max_concurrent_requests": schema.Int64Attribute{
+										Computed: true,
+									},
+									"pooling": schema.StringAttribute{
+										Computed: true,
+									},
+								},
+							},
+							"tgi": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"health_route": schema.StringAttribute{
+										Computed: true,
+									},
+									"port": schema.Int64Attribute{
+										Computed: true,
+									},
+									"url": schema.StringAttribute{
+										Computed: true,
+									},
+									"max_batch_prefill_tokens": schema.Int64Attribute{
+										Computed: true,
+									},
+									"max_batch_total_tokens": schema.Int64Attribute{
+										Computed: true,
+									},
+									"max_input_length": schema.Int64Attribute{
+										Computed: true,
+									},
+									"max_total_tokens": schema.Int64Attribute{
+										Computed: true,
+									},
+									"disable_custom_kernels": schema.BoolAttribute{
+										Computed: true,
+									},
+									"diable_custom_kernels": schema.BoolAttribute{
+										Computed:           true,
+										DeprecationMessage: "Use disable_custom_kernels instead.",
+									},
+									"quantize": schema.StringAttribute{
+										Computed: true,
+									},
+								},
+							},
+							"vllm": schema.SingleNestedAttribute{
+								Computed: true,
+								Attributes: map[string]schema.Attribute{
+									"health_route": schema.StringAttribute{
+										Computed: true,
+									},
+									"port": schema.Int64Attribute{
+										Computed: true,
+									},
+									"url": schema.StringAttribute{
+										Computed: true,
+									},
+									"kv_cache_dtype": schema.StringAttribute{
+										Computed: true,
+									},
+									"max_num_batched_tokens": schema.Int64Attribute{
+										Computed: true,
+									},
+									"max_num_seqs": schema.Int64Attribute{
+										Computed: true,
+									},
+									"tensor_parallel_size": schema.Int64Attribute{
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+					"repository": schema.StringAttribute{
+						Computed: true,
+					},
+					"revision": schema.StringAttribute{
+						Computed: true,
+					},
+					"task": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// recommendedImageKind picks the serving container for a model from its
+// pipeline tag: TEI for embeddings and rerankers, TGI for text generation and
+// the default Hugging Face container for everything else.
+func recommendedImageKind(model HubModel) string {
+	switch model.PipelineTag {
+	case "feature-extraction", "sentence-similarity":
+		return "tei"
+	case "text-generation", "text2text-generation", "image-text-to-text":
+		return "tgi"
+	case "text-classification":
+		for _, tag := range model.Tags {
+			if tag == "text-embeddings-inference" {
+				return "tei"
+			}
+		}
+	}
+	return "huggingface"
+}
+
+// recommendedTask maps a pipeline tag onto the task the recommended image
+// serves it as.
+func recommendedTask(model HubModel, image string) string {
+	if image == "tei" {
+		if model.PipelineTag == "text-classification" {
+			return "sentence-ranking"
+		}
+		return "sentence-embeddings"
+	}
+	if image == "tgi" || image == "vllm" {
+		return "text-generation"
+	}
+	if model.PipelineTag == "" {
+		return "custom"
+	}
+	return model.PipelineTag
+}
+
+// smallModelGb is the estimated footprint below which encoder models are
+// recommended a CPU instance instead of a GPU.
+const smallModelGb = 2
+
+func recommendEndpoint(model HubModel, catalog EndpointCatalog, vendor string, imageKind string) (endpointRecommendationDataSourceModel, error) {
+	if imageKind == "" {
+		imageKind = recommendedImageKind(model)
+	}
+
+	if len(catalog.computes(vendor)) == 0 {
+		return endpointRecommendationDataSourceModel{}, fmt.Errorf("the endpoint catalog has no available instances for vendor %q", vendor)
+	}
+
+	requiredGb := 0.0
+	if model.Safetensors != nil {
+		requiredGb = estimateModelMemoryGb(*model.Safetensors, "")
+	}
+	generative := imageKind == "tgi" || imageKind == "vllm"
+
+	recommendation, ok := InstanceRecommendation{}, false
+	if !generative && model.Safetensors != nil && requiredGb <= smallModelGb {
+		recommendation, ok = catalog.recommendInstance(vendor, "cpu", requiredGb)
+	}
+	if !ok {
+		recommendation, ok = catalog.recommendInstance(vendor, "gpu", requiredGb)
+	}
+	if !ok {
+		return endpointRecommendationDataSourceModel{}, fmt.Errorf(
+			"%s needs an estimated %.1f GB of accelerator memory and no %s instance in the catalog is large enough",
+			model.ID, requiredGb, vendor,
+		)
+	}
+
+	task := recommendedTask(model, imageKind)
+	compute := Compute{
+		Accelerator:  recommendation.Accelerator,
+		InstanceType: recommendation.InstanceType,
+		InstanceSize: recommendation.InstanceSize,
+		Scaling: Scaling{
+			MinReplica:         0,
+			MaxReplica:         1,
+			ScaleToZeroTimeout: types.Int64Value(defaultScaleToZeroTimeout),
+		},
+	}
+	accelerators := recommendation.Accelerators
+	if accelerators < 1 {
+		accelerators = 1
+	}
+
+	var image RecommendationImage
+	switch imageKind {
+	case "tei":
+		url := teiGPUImageURL
+		if compute.Accelerator == "cpu" {
+			url = teiCPUImageURL
+		}
+		image.Tei = &Tei{
+			Port: types.Int64Value(80),
+			URL:  url,
+		}
+	case "tgi":
+		image.Tgi = &Tgi{
+			Port: types.Int64Value(80),
+			URL:  tgiImageURL,
+		}
+	case "vllm":
+		tensorParallelSize := accelerators
+		image.Vllm = &Vllm{
+			Port:               types.Int64Value(8000),
+			URL:                vllmImageURL,
+			TensorParallelSize: &tensorParallelSize,
+		}
+	default:
+		image.Huggingface = &Huggingface{}
+	}
+
+	framework := hubModelFramework(model)
+	if imageKind != "huggingface" && framework == "custom" {
+		framework = "pytorch"
+	}
+
+	return endpointRecommendationDataSourceModel{
+		Vendor:  types.StringValue(vendor),
+		Image:   types.StringValue(imageKind),
+		Compute: compute,
+		Model: RecommendationModel{
+			Framework:  framework,
+			Image:      image,
+			Repository: model.ID,
+			Revision:   model.Sha,
+			Task:       task,
+			Env:        make(map[string]string),
+		},
+	}, nil
+}
+
+func (d *endpointRecommendationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config endpointRecommendationDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model, err := d.hub.GetModel(config.Repository.ValueString(), config.Revision.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"model not found",
+				"could not find model repository "+config.Repository.ValueString()+" on the hub",
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"error reading model",
+			"could not read model repository "+config.Repository.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	vendor := "aws"
+	if !config.Vendor.IsNull() && !config.Vendor.IsUnknown() {
+		vendor = strings.ToLower(config.Vendor.ValueString())
+	}
+
+	catalog, err := getEndpointCatalog(d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading endpoint catalog",
+			"could not read the inference endpoints catalog: "+err.Error(),
+		)
+		return
+	}
+
+	state, err := recommendEndpoint(model, catalog, vendor, config.Image.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"no recommended configuration",
+			err.Error(),
+		)
+		return
+	}
+	state.Repository = config.Repository
+	state.Revision = config.Revision
+	state.Model.Repository = config.Repository.ValueString()
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
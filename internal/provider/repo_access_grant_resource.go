@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &repoAccessGrantResource{}
+	_ resource.ResourceWithConfigure   = &repoAccessGrantResource{}
+	_ resource.ResourceWithImportState = &repoAccessGrantResource{}
+)
+
+func NewRepoAccessGrantResource() resource.Resource {
+	return &repoAccessGrantResource{}
+}
+
+type repoAccessGrantResource struct {
+	hub *hubClient
+}
+
+type repoAccessGrantResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	RepoID   types.String `tfsdk:"repo_id"`
+	RepoType types.String `tfsdk:"repo_type"`
+	User     types.String `tfsdk:"user"`
+}
+
+func (r *repoAccessGrantResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repoAccessGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_access_grant"
+}
+
+func (r *repoAccessGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Gives a user access to a gated repository. A pending or previously rejected access request from the user is accepted; otherwise access is granted directly. Destroying the resource rejects the user's request, revoking access.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The grant ID (\"namespace/name:user\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The ID (\"namespace/name\") of a gated repository. Changing it replaces the grant.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Changing it replaces the grant.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				MarkdownDescription: "The username to grant access to. Changing it replaces the grant.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// findAccessRequest looks for the request of user among the requests in any
+// of the given statuses.
+func (r *repoAccessGrantResource) findAccessRequest(repoType string, repoID string, user string, statuses ...string) (*HubAccessRequest, error) {
+	for _, status := range statuses {
+		requests, err := r.hub.ListAccessRequests(repoType, repoID, status)
+		if err != nil {
+			return nil, err
+		}
+		for _, request := range requests {
+			if strings.EqualFold(request.User.User, user) {
+				return &request, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *repoAccessGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repoAccessGrantResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := plan.RepoType.ValueString()
+	repoID := plan.RepoID.ValueString()
+	user := plan.User.ValueString()
+
+	// Users who asked for access, or were turned down before, have a request
+	// to accept. Anyone else is granted access directly.
+	request, err := r.findAccessRequest(repoType, repoID, user, "pending", "rejected")
+	if err == nil && request != nil {
+		err = r.hub.HandleAccessRequest(repoType, repoID, HubHandleAccessRequest{User: user, Status: "accepted"})
+	} else if err == nil {
+		err = r.hub.GrantAccess(repoType, repoID, user)
+		// The user already has access.
+		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusConflict {
+			err = nil
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error granting repo access",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(repoID + ":" + user)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoAccessGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repoAccessGrantResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request, err := r.findAccessRequest(state.RepoType.ValueString(), state.RepoID.ValueString(), state.User.ValueString(), "accepted")
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repo access grant",
+				"could not read access requests of "+state.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if request == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoAccessGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Every argument forces replacement, so there is nothing to update.
+	var plan repoAccessGrantResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoAccessGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repoAccessGrantResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.HandleAccessRequest(state.RepoType.ValueString(), state.RepoID.ValueString(), HubHandleAccessRequest{
+		User:   state.User.ValueString(),
+		Status: "rejected",
+	})
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error revoking repo access",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *repoAccessGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, user, err := parseRepoChildImportID(req.ID, "user")
+	if err != nil {
+		resp.Diagnostics.AddError("invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoID+":"+user)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ datasource.DataSource              = &repoAccessRequestsDataSource{}
+	_ datasource.DataSourceWithConfigure = &repoAccessRequestsDataSource{}
+)
+
+func NewRepoAccessRequestsDataSource() datasource.DataSource {
+	return &repoAccessRequestsDataSource{}
+}
+
+type repoAccessRequestsDataSource struct {
+	hub *hubClient
+}
+
+type repoAccessRequestsDataSourceModel struct {
+	RepoID   types.String    `tfsdk:"repo_id"`
+	RepoType types.String    `tfsdk:"repo_type"`
+	Status   types.String    `tfsdk:"status"`
+	Requests []AccessRequest `tfsdk:"requests"`
+}
+
+type AccessRequest struct {
+	User      string            `tfsdk:"user"`
+	Fullname  string            `tfsdk:"fullname"`
+	Email     string            `tfsdk:"email"`
+	Status    string            `tfsdk:"status"`
+	Timestamp string            `tfsdk:"timestamp"`
+	Fields    map[string]string `tfsdk:"fields"`
+}
+
+func (d *repoAccessRequestsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected data source configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	d.hub = data.hub
+}
+
+func (d *repoAccessRequestsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_access_requests"
+}
+
+func (d *repoAccessRequestsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the access requests of a gated repository.",
+		Attributes: map[string]schema.Attribute{
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\").",
+				Required:            true,
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\".",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only lists \"pending\", \"accepted\" or \"rejected\" requests. Defaults to all three.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(accessRequestStatuses...),
+				},
+			},
+			"requests": schema.ListNestedAttribute{
+				MarkdownDescription: "The access requests.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The username.",
+							Computed:            true,
+						},
+						"fullname": schema.StringAttribute{
+							MarkdownDescription: "The user's full name.",
+							Computed:            true,
+						},
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The user's email address.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The request status: \"pending\", \"accepted\" or \"rejected\".",
+							Computed:            true,
+						},
+						"timestamp": schema.StringAttribute{
+							MarkdownDescription: "The time of the request.",
+							Computed:            true,
+						},
+						"fields": schema.MapAttribute{
+							MarkdownDescription: "The answers to the gating form.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hubAccessRequestToAccessRequest flattens the answers to the gating form,
+// which can be of any JSON type, to strings.
+func hubAccessRequestToAccessRequest(request HubAccessRequest) AccessRequest {
+	fields := make(map[string]string, len(request.Fields))
+	for key, value := range request.Fields {
+		if str, ok := value.(string); ok {
+			fields[key] = str
+		} else {
+			fields[key] = fmt.Sprint(value)
+		}
+	}
+
+	return AccessRequest{
+		User:      request.User.User,
+		Fullname:  request.User.Fullname,
+		Email:     request.User.Email,
+		Status:    request.Status,
+		Timestamp: request.Timestamp,
+		Fields:    fields,
+	}
+}
+
+func (d *repoAccessRequestsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config repoAccessRequestsDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := config.RepoType.ValueString()
+	if repoType == "" {
+		repoType = "model"
+	}
+	statuses := accessRequestStatuses
+	if !config.Status.IsNull() {
+		statuses = []string{config.Status.ValueString()}
+	}
+
+	state := config
+	state.Requests = []AccessRequest{}
+	for _, status := range statuses {
+		requests, err := d.hub.ListAccessRequests(repoType, config.RepoID.ValueString(), status)
+		if err != nil {
+			if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+				resp.Diagnostics.AddError(
+					"repository not found",
+					"could not find repository "+config.RepoID.ValueString()+" on the hub, or it is not gated",
+				)
+				return
+			}
+			resp.Diagnostics.AddError(
+				"error reading access requests",
+				"could not read "+status+" access requests of "+config.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		for _, request := range requests {
+			// The status is implied by the list the request came from.
+			if request.Status == "" {
+				request.Status = status
+			}
+			state.Requests = append(state.Requests, hubAccessRequestToAccessRequest(request))
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,309 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &repoBranchResource{}
+	_ resource.ResourceWithConfigure   = &repoBranchResource{}
+	_ resource.ResourceWithImportState = &repoBranchResource{}
+)
+
+func NewRepoBranchResource() resource.Resource {
+	return &repoBranchResource{}
+}
+
+type repoBranchResource struct {
+	hub *hubClient
+}
+
+type repoBranchResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	RepoID    types.String `tfsdk:"repo_id"`
+	RepoType  types.String `tfsdk:"repo_type"`
+	Name      types.String `tfsdk:"name"`
+	Revision  types.String `tfsdk:"revision"`
+	CommitSha types.String `tfsdk:"commit_sha"`
+}
+
+func (r *repoBranchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repoBranchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_branch"
+}
+
+func (r *repoBranchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a branch of a repository pointing at a revision.\n\nImported branches remember that they were imported, so setting or changing `revision` in their configuration updates the state instead of replacing the branch.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The branch ID (\"namespace/name:branch\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\"). Changing it replaces the branch.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Changing it replaces the branch.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The branch name. Changing it replaces the branch.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit to create the branch from. Defaults to the head of the default branch. Changing it replaces the branch.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The commit the branch points at. It follows new commits to the branch.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// importedPrivateKey is the private state key ImportState sets on refs, so
+// their arguments can be told apart from ones the ref was created with.
+const importedPrivateKey = "imported"
+
+// markImported records in private state that a resource was imported.
+func markImported(ctx context.Context, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, importedPrivateKey, []byte("true"))...)
+}
+
+// requiresReplaceUnlessImported replaces a ref when an argument it was
+// created with changes. Imported refs weren't created with those arguments,
+// and setting them in the configuration afterwards shouldn't recreate the
+// ref.
+func requiresReplaceUnlessImported() planmodifier.String {
+	return stringplanmodifier.RequiresReplaceIf(
+		func(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+			imported, diags := req.Private.GetKey(ctx, importedPrivateKey)
+			resp.Diagnostics.Append(diags...)
+			resp.RequiresReplace = string(imported) != "true"
+		},
+		"Changing this value replaces the ref, unless the ref was imported.",
+		"Changing this value replaces the ref, unless the ref was imported.",
+	)
+}
+
+// repoRefID builds the id of a branch or tag, "namespace/name:ref".
+func repoRefID(repoID string, ref string) string {
+	return repoID + ":" + ref
+}
+
+// parseRepoChildImportID parses the import id of something that lives in a
+// repo, such as a ref: "namespace/name:child" for models and
+// "type/namespace/name:child" for any repo type. child only names the last
+// part in the error message.
+func parseRepoChildImportID(importID string, child string) (string, string, string, error) {
+	i := strings.LastIndex(importID, ":")
+	if i < 0 || importID[i+1:] == "" {
+		return "", "", "", fmt.Errorf("expected an import id of the form namespace/name:%s or type/namespace/name:%s, got %q", child, child, importID)
+	}
+	repoType, repoID, err := parseRepoImportID(importID[:i])
+	if err != nil {
+		return "", "", "", fmt.Errorf("expected an import id of the form namespace/name:%s or type/namespace/name:%s, got %q", child, child, importID)
+	}
+	return repoType, repoID, importID[i+1:], nil
+}
+
+func findGitRef(refs []HubGitRef, name string) (HubGitRef, bool) {
+	for _, ref := range refs {
+		if ref.Name == name {
+			return ref, true
+		}
+	}
+	return HubGitRef{}, false
+}
+
+func (r *repoBranchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repoBranchResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := plan.RepoType.ValueString()
+	repoID := plan.RepoID.ValueString()
+	err := r.hub.CreateBranch(repoType, repoID, plan.Name.ValueString(), plan.Revision.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating repo branch",
+			err.Error(),
+		)
+		return
+	}
+
+	refs, err := r.hub.GetRepoRefs(repoType, repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading repo branch",
+			"could not read refs of "+repoID+": "+err.Error(),
+		)
+		return
+	}
+	branch, ok := findGitRef(refs.Branches, plan.Name.ValueString())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"error reading repo branch",
+			"branch "+plan.Name.ValueString()+" is missing from "+repoID+" after creation",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(repoRefID(repoID, plan.Name.ValueString()))
+	plan.CommitSha = types.StringValue(branch.TargetCommit)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoBranchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repoBranchResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refs, err := r.hub.GetRepoRefs(state.RepoType.ValueString(), state.RepoID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repo branch",
+				"could not read refs of "+state.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	branch, ok := findGitRef(refs.Branches, state.Name.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// A branch moves as commits land on it, so a new head isn't drift.
+	state.CommitSha = types.StringValue(branch.TargetCommit)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoBranchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only the revision of an imported branch can change in place, and it
+	// doesn't move the branch.
+	var plan repoBranchResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoBranchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repoBranchResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteBranch(state.RepoType.ValueString(), state.RepoID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting repo branch",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *repoBranchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, name, err := parseRepoChildImportID(req.ID, "branch")
+	if err != nil {
+		resp.Diagnostics.AddError("invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoRefID(repoID, name))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	markImported(ctx, resp)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestParseRepoChildImportID(t *testing.T) {
+	tests := map[string]struct {
+		importID     string
+		wantRepoType string
+		wantRepoID   string
+		wantChild    string
+		wantError    bool
+	}{
+		"model": {
+			importID:     "acme/model:release",
+			wantRepoType: "model",
+			wantRepoID:   "acme/model",
+			wantChild:    "release",
+		},
+		"dataset": {
+			importID:     "dataset/acme/data:v1",
+			wantRepoType: "dataset",
+			wantRepoID:   "acme/data",
+			wantChild:    "v1",
+		},
+		"space": {
+			importID:     "space/acme/demo:main",
+			wantRepoType: "space",
+			wantRepoID:   "acme/demo",
+			wantChild:    "main",
+		},
+		"child containing a slash": {
+			importID:     "acme/model:release/v1",
+			wantRepoType: "model",
+			wantRepoID:   "acme/model",
+			wantChild:    "release/v1",
+		},
+		"missing child": {
+			importID:  "acme/model",
+			wantError: true,
+		},
+		"empty child": {
+			importID:  "acme/model:",
+			wantError: true,
+		},
+		"unknown repo type": {
+			importID:  "bucket/acme/model:release",
+			wantError: true,
+		},
+		"missing namespace": {
+			importID:  "model:release",
+			wantError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repoType, repoID, child, err := parseRepoChildImportID(test.importID, "branch")
+			if test.wantError {
+				if err == nil {
+					t.Fatalf("expected an error, got %q, %q, %q", repoType, repoID, child)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if repoType != test.wantRepoType || repoID != test.wantRepoID || child != test.wantChild {
+				t.Errorf("got %q, %q, %q, want %q, %q, %q", repoType, repoID, child, test.wantRepoType, test.wantRepoID, test.wantChild)
+			}
+		})
+	}
+}
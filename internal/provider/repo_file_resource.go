@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource               = &repoFileResource{}
+	_ resource.ResourceWithConfigure  = &repoFileResource{}
+	_ resource.ResourceWithModifyPlan = &repoFileResource{}
+)
+
+func NewRepoFileResource() resource.Resource {
+	return &repoFileResource{}
+}
+
+type repoFileResource struct {
+	hub *hubClient
+}
+
+type repoFileResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	RepoID        types.String `tfsdk:"repo_id"`
+	RepoType      types.String `tfsdk:"repo_type"`
+	Path          types.String `tfsdk:"path"`
+	Content       types.String `tfsdk:"content"`
+	Source        types.String `tfsdk:"source"`
+	Branch        types.String `tfsdk:"branch"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	Sha256        types.String `tfsdk:"sha256"`
+	BlobSha       types.String `tfsdk:"blob_sha"`
+	Lfs           types.Bool   `tfsdk:"lfs"`
+	CommitSha     types.String `tfsdk:"commit_sha"`
+}
+
+func (r *repoFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repoFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_file"
+}
+
+func (r *repoFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Commits a single file to a model, dataset or Space repository. Files the Hub stores in LFS, such as weights, archives and other large binaries, are uploaded to LFS storage automatically.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The file ID (\"namespace/name:branch:path\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\"). Changing it replaces the file.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Changing it replaces the file.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				MarkdownDescription: "The path of the file in the repository. Changing it replaces the file.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"content": schema.StringAttribute{
+				MarkdownDescription: "The file content. Exactly one of `content` and `source` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("source")),
+				},
+			},
+			"source": schema.StringAttribute{
+				MarkdownDescription: "The path of a local file to upload. Edits to the file are detected by its hash.",
+				Optional:            true,
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "The branch to commit to. Defaults to \"main\". Changing it replaces the file.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("main"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"commit_message": schema.StringAttribute{
+				MarkdownDescription: "The commit summary. Defaults to \"Upload <path>\" or \"Update <path>\".",
+				Optional:            true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "The SHA-256 of the content.",
+				Computed:            true,
+			},
+			"blob_sha": schema.StringAttribute{
+				MarkdownDescription: "The git blob ID of the file on the Hub. If it changes outside Terraform, the next apply overwrites the file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"lfs": schema.BoolAttribute{
+				MarkdownDescription: "Whether the file is stored in LFS.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The commit that last wrote the file.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func repoFileID(repoID string, branch string, filePath string) string {
+	return repoID + ":" + branch + ":" + filePath
+}
+
+// commitAdd builds the file to commit from either the inline content or
+// the local source file.
+func (m repoFileResourceModel) commitAdd() (*HubCommitAdd, error) {
+	if !m.Source.IsNull() {
+		return newCommitAddFromFile(m.Path.ValueString(), m.Source.ValueString())
+	}
+	return newCommitAddFromBytes(m.Path.ValueString(), []byte(m.Content.ValueString())), nil
+}
+
+// ModifyPlan hashes the local content so that edits to a source file, which
+// Terraform can't see in the configuration, still produce a diff.
+func (r *repoFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan repoFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Content.IsUnknown() || plan.Source.IsUnknown() || plan.Path.IsUnknown() {
+		return
+	}
+
+	add, err := plan.commitAdd()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("source"),
+			"error reading source file",
+			err.Error(),
+		)
+		return
+	}
+	plan.Sha256 = types.StringValue(add.Sha256)
+
+	if !req.State.Raw.IsNull() {
+		var state repoFileResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if state.Sha256.ValueString() != add.Sha256 {
+			plan.BlobSha = types.StringUnknown()
+			plan.Lfs = types.BoolUnknown()
+			plan.CommitSha = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// commitFile uploads the planned content and fills in the computed
+// attributes from the resulting commit.
+func (r *repoFileResource) commitFile(plan *repoFileResourceModel, defaultMessage string) error {
+	add, err := plan.commitAdd()
+	if err != nil {
+		return err
+	}
+
+	message := plan.CommitMessage.ValueString()
+	if message == "" {
+		message = defaultMessage + " " + plan.Path.ValueString()
+	}
+
+	commit, err := r.hub.CreateCommit(plan.RepoType.ValueString(), plan.RepoID.ValueString(), plan.Branch.ValueString(), message, []*HubCommitAdd{add}, nil)
+	if err != nil {
+		return err
+	}
+
+	infos, err := r.hub.GetPathsInfo(plan.RepoType.ValueString(), plan.RepoID.ValueString(), commit.CommitOid, []string{plan.Path.ValueString()})
+	if err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return fmt.Errorf("file %s is missing from commit %s", plan.Path.ValueString(), commit.CommitOid)
+	}
+
+	plan.Sha256 = types.StringValue(add.Sha256)
+	plan.BlobSha = types.StringValue(infos[0].Oid)
+	plan.Lfs = types.BoolValue(infos[0].Lfs != nil)
+	plan.CommitSha = types.StringValue(commit.CommitOid)
+
+	return nil
+}
+
+func (r *repoFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repoFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.commitFile(&plan, "Upload")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating repo file",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(repoFileID(plan.RepoID.ValueString(), plan.Branch.ValueString(), plan.Path.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repoFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	infos, err := r.hub.GetPathsInfo(state.RepoType.ValueString(), state.RepoID.ValueString(), state.Branch.ValueString(), []string{state.Path.ValueString()})
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repo file",
+				"could not read "+state.Path.ValueString()+" in "+state.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+	if len(infos) == 0 || infos[0].Type != "file" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The blob id changes whenever the file is edited outside Terraform. The
+	// sha256 of the new content is only known for LFS files; otherwise it is
+	// cleared so that the next plan overwrites the remote edit.
+	info := infos[0]
+	if info.Oid != state.BlobSha.ValueString() {
+		state.BlobSha = types.StringValue(info.Oid)
+		state.Lfs = types.BoolValue(info.Lfs != nil)
+		if info.Lfs != nil {
+			state.Sha256 = types.StringValue(info.Lfs.Oid)
+		} else {
+			state.Sha256 = types.StringValue("")
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state repoFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Changing only the commit message, or switching between content and
+	// source with the same bytes, doesn't need a new commit.
+	if plan.Sha256.IsUnknown() || plan.Sha256.ValueString() != state.Sha256.ValueString() {
+		err := r.commitFile(&plan, "Update")
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating repo file",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repoFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.hub.CreateCommit(state.RepoType.ValueString(), state.RepoID.ValueString(), state.Branch.ValueString(), "Delete "+state.Path.ValueString(), nil, []string{state.Path.ValueString()})
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting repo file",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
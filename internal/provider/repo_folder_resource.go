@@ -0,0 +1,634 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource               = &repoFolderResource{}
+	_ resource.ResourceWithConfigure  = &repoFolderResource{}
+	_ resource.ResourceWithModifyPlan = &repoFolderResource{}
+)
+
+func NewRepoFolderResource() resource.Resource {
+	return &repoFolderResource{}
+}
+
+type repoFolderResource struct {
+	hub *hubClient
+}
+
+type repoFolderResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	RepoID        types.String `tfsdk:"repo_id"`
+	RepoType      types.String `tfsdk:"repo_type"`
+	SourceDir     types.String `tfsdk:"source_dir"`
+	PathInRepo    types.String `tfsdk:"path_in_repo"`
+	Branch        types.String `tfsdk:"branch"`
+	Include       types.List   `tfsdk:"include"`
+	Exclude       types.List   `tfsdk:"exclude"`
+	CommitMessage types.String `tfsdk:"commit_message"`
+	Files         types.Map    `tfsdk:"files"`
+	BlobShas      types.Map    `tfsdk:"blob_shas"`
+	CommitSha     types.String `tfsdk:"commit_sha"`
+}
+
+func (r *repoFolderResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repoFolderResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_folder"
+}
+
+func (r *repoFolderResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Syncs a local directory to a repository in a single commit. Only files whose content differs from the branch are uploaded, large files go through LFS, and files the resource uploaded that were since removed locally, or that `include` and `exclude` no longer match, are deleted from the repository. The resulting `commit_sha` can be deployed directly, so retraining and redeploying is one `terraform apply`.\n\nOnly files the resource uploaded are ever deleted. Other files under `path_in_repo`, such as ones added on the Hub or by another resource, are left alone and never adopted, and `.gitattributes` is never managed. Destroying the resource deletes the managed files in one commit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The folder ID (\"namespace/name:branch:path_in_repo\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\"). Changing it replaces the resource.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_dir": schema.StringAttribute{
+				MarkdownDescription: "The local directory to upload. A `.git` directory inside it is ignored.",
+				Required:            true,
+			},
+			"path_in_repo": schema.StringAttribute{
+				MarkdownDescription: "The directory of the repository to sync into. Defaults to the repository root. Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"branch": schema.StringAttribute{
+				MarkdownDescription: "The branch to commit to. Defaults to \"main\". Changing it replaces the resource.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("main"),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"include": schema.ListAttribute{
+				MarkdownDescription: "Glob patterns of the files to manage. Defaults to all files. Patterns are matched against the path relative to `source_dir` and against the file name, so `\"*.bin\"` matches at any depth. Managed files that no longer match are deleted from the repository.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"exclude": schema.ListAttribute{
+				MarkdownDescription: "Glob patterns of the files not to manage, matched like `include`. Managed files that a new pattern excludes are deleted from the repository.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"commit_message": schema.StringAttribute{
+				MarkdownDescription: "The commit summary.",
+				Optional:            true,
+			},
+			"files": schema.MapAttribute{
+				MarkdownDescription: "Map of repository path to the SHA-256 of each managed file.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"blob_shas": schema.MapAttribute{
+				MarkdownDescription: "Map of repository path to the git blob ID of each managed file on the Hub, used to detect edits made outside Terraform.",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The head of the branch after the last sync.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// folderFilter decides which files of the folder are managed, both locally
+// and in the repository. Patterns are matched against the path relative to
+// the folder and against the file name, so "*.bin" matches at any depth.
+type folderFilter struct {
+	include []string
+	exclude []string
+}
+
+func matchAny(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, relPath); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(relPath)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f folderFilter) matches(relPath string) bool {
+	if len(f.include) > 0 && !matchAny(f.include, relPath) {
+		return false
+	}
+	return !matchAny(f.exclude, relPath)
+}
+
+func (m repoFolderResourceModel) filter(ctx context.Context) (folderFilter, diag.Diagnostics) {
+	var filter folderFilter
+	var diags diag.Diagnostics
+	if !m.Include.IsNull() {
+		diags.Append(m.Include.ElementsAs(ctx, &filter.include, false)...)
+	}
+	if !m.Exclude.IsNull() {
+		diags.Append(m.Exclude.ElementsAs(ctx, &filter.exclude, false)...)
+	}
+	return filter, diags
+}
+
+// repoPath maps a path relative to the folder to its path in the repository.
+func (m repoFolderResourceModel) repoPath(relPath string) string {
+	prefix := strings.Trim(m.PathInRepo.ValueString(), "/")
+	if prefix == "" {
+		return relPath
+	}
+	return prefix + "/" + relPath
+}
+
+// folderName describes the folder in default commit messages.
+func (m repoFolderResourceModel) folderName() string {
+	if prefix := strings.Trim(m.PathInRepo.ValueString(), "/"); prefix != "" {
+		return prefix
+	}
+	return "folder"
+}
+
+// relPath is the inverse of repoPath. It returns false for repository paths
+// outside of path_in_repo.
+func (m repoFolderResourceModel) relPath(repoPath string) (string, bool) {
+	prefix := strings.Trim(m.PathInRepo.ValueString(), "/")
+	if prefix == "" {
+		return repoPath, true
+	}
+	if !strings.HasPrefix(repoPath, prefix+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(repoPath, prefix+"/"), true
+}
+
+// localFiles walks source_dir and hashes every file that passes the filter.
+// The .git directory of a checkout is always skipped.
+func (m repoFolderResourceModel) localFiles(filter folderFilter) (map[string]*HubCommitAdd, error) {
+	root := m.SourceDir.ValueString()
+	files := make(map[string]*HubCommitAdd)
+	err := filepath.WalkDir(root, func(localPath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" && localPath != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, localPath)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !filter.matches(rel) {
+			return nil
+		}
+
+		add, err := newCommitAddFromFile(m.repoPath(rel), localPath)
+		if err != nil {
+			return err
+		}
+		files[add.Path] = add
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// remoteFiles lists the files of the branch below path_in_repo, whether or
+// not include and exclude match them, so that tracked files a filter change
+// leaves out can still be found and deleted. The .gitattributes file is
+// never managed, since the Hub relies on it to route large files to LFS.
+func (r *repoFolderResource) remoteFiles(m repoFolderResourceModel, revision string) (map[string]HubPathInfo, error) {
+	entries, err := r.hub.GetRepoTree(m.RepoType.ValueString(), m.RepoID.ValueString(), revision, strings.Trim(m.PathInRepo.ValueString(), "/"))
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound && m.PathInRepo.ValueString() != "" {
+			// The folder doesn't exist yet.
+			return map[string]HubPathInfo{}, nil
+		}
+		return nil, err
+	}
+
+	files := make(map[string]HubPathInfo)
+	for _, entry := range entries {
+		if entry.Type != "file" || entry.Path == ".gitattributes" {
+			continue
+		}
+		if _, ok := m.relPath(entry.Path); !ok {
+			continue
+		}
+		files[entry.Path] = entry
+	}
+
+	return files, nil
+}
+
+// folderChanges compares the local files with the branch. It returns the
+// local files that are missing from the branch or differ from it, and the
+// tracked files, those recorded in the prior state, that are no longer
+// wanted: removed from source_dir or no longer matched by include and
+// exclude. Tracked files that are already gone from the branch aren't
+// deleted again, since a commit deleting a missing file is rejected.
+func folderChanges(local map[string]*HubCommitAdd, remote map[string]HubPathInfo, tracked map[string]string) ([]*HubCommitAdd, []string) {
+	var adds []*HubCommitAdd
+	for filePath, add := range local {
+		if info, ok := remote[filePath]; !ok || !info.Matches(add) {
+			adds = append(adds, add)
+		}
+	}
+	var deletes []string
+	for filePath := range tracked {
+		_, isLocal := local[filePath]
+		if _, isRemote := remote[filePath]; isRemote && !isLocal {
+			deletes = append(deletes, filePath)
+		}
+	}
+	sort.Slice(adds, func(i, j int) bool { return adds[i].Path < adds[j].Path })
+	sort.Strings(deletes)
+	return adds, deletes
+}
+
+func fileHashes(files map[string]*HubCommitAdd) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for filePath, add := range files {
+		hashes[filePath] = add.Sha256
+	}
+	return hashes
+}
+
+// ModifyPlan hashes the local folder so that changed, added or removed files
+// show up in the plan even though the configuration didn't change.
+func (r *repoFolderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan repoFolderResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.SourceDir.IsUnknown() || plan.PathInRepo.IsUnknown() || plan.Include.IsUnknown() || plan.Exclude.IsUnknown() {
+		return
+	}
+
+	filter, diags := plan.filter(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	files, err := plan.localFiles(filter)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("source_dir"),
+			"error reading source directory",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Files, diags = types.MapValueFrom(ctx, types.StringType, fileHashes(files))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state repoFolderResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.Files.Equal(plan.Files) {
+			plan.BlobShas = types.MapUnknown(types.StringType)
+			plan.CommitSha = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+// sync uploads the local files that differ from the branch and deletes the
+// tracked files that are no longer wanted, all in one commit. Other files
+// below path_in_repo are left alone.
+// When the branch already matches, no commit is made and the current head is
+// kept.
+func (r *repoFolderResource) sync(ctx context.Context, plan *repoFolderResourceModel, tracked map[string]string, defaultMessage string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	filter, filterDiags := plan.filter(ctx)
+	diags.Append(filterDiags...)
+	if diags.HasError() {
+		return diags
+	}
+
+	local, err := plan.localFiles(filter)
+	if err != nil {
+		diags.AddAttributeError(tfpath.Root("source_dir"), "error reading source directory", err.Error())
+		return diags
+	}
+
+	repoType := plan.RepoType.ValueString()
+	repoID := plan.RepoID.ValueString()
+	branch := plan.Branch.ValueString()
+	remote, err := r.remoteFiles(*plan, branch)
+	if err != nil {
+		diags.AddError("error listing repository files", err.Error())
+		return diags
+	}
+
+	adds, deletes := folderChanges(local, remote, tracked)
+
+	var commitSha string
+	if len(adds) > 0 || len(deletes) > 0 {
+		message := plan.CommitMessage.ValueString()
+		if message == "" {
+			message = defaultMessage + " " + plan.folderName()
+		}
+		commit, err := r.hub.CreateCommit(repoType, repoID, branch, message, adds, deletes)
+		if err != nil {
+			diags.AddError("error committing folder", err.Error())
+			return diags
+		}
+		commitSha = commit.CommitOid
+	} else {
+		head, err := r.hub.GetRepoRevision(repoType, repoID, branch)
+		if err != nil {
+			diags.AddError("error reading branch head", err.Error())
+			return diags
+		}
+		commitSha = head.Sha
+	}
+
+	// Record the blob ids as the Hub sees them, LFS pointers included, so Read
+	// can tell when a file was changed outside Terraform.
+	committed, err := r.remoteFiles(*plan, commitSha)
+	if err != nil {
+		diags.AddError("error listing repository files", err.Error())
+		return diags
+	}
+	blobShas := make(map[string]string, len(local))
+	for filePath := range local {
+		if info, ok := committed[filePath]; ok {
+			blobShas[filePath] = info.Oid
+		}
+	}
+
+	var valueDiags diag.Diagnostics
+	plan.Files, valueDiags = types.MapValueFrom(ctx, types.StringType, fileHashes(local))
+	diags.Append(valueDiags...)
+	plan.BlobShas, valueDiags = types.MapValueFrom(ctx, types.StringType, blobShas)
+	diags.Append(valueDiags...)
+	plan.CommitSha = types.StringValue(commitSha)
+
+	return diags
+}
+
+func (r *repoFolderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repoFolderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, nil, "Upload")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(repoFileID(plan.RepoID.ValueString(), plan.Branch.ValueString(), plan.PathInRepo.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFolderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repoFolderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.remoteFiles(state, state.Branch.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repo folder",
+				"could not list files of "+state.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	var files, blobShas map[string]string
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &files, false)...)
+	resp.Diagnostics.Append(state.BlobShas.ElementsAs(ctx, &blobShas, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if files == nil {
+		files = make(map[string]string)
+	}
+	if blobShas == nil {
+		blobShas = make(map[string]string)
+	}
+
+	// Tracked files edited or deleted outside Terraform are recorded with an
+	// empty hash or dropped, so that the next plan syncs them back. Files
+	// added outside Terraform aren't tracked and are left alone.
+	for filePath := range files {
+		info, ok := remote[filePath]
+		if !ok {
+			delete(files, filePath)
+			delete(blobShas, filePath)
+			continue
+		}
+		if blobShas[filePath] != info.Oid {
+			files[filePath] = ""
+			blobShas[filePath] = info.Oid
+		}
+	}
+
+	state.Files, diags = types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+	state.BlobShas, diags = types.MapValueFrom(ctx, types.StringType, blobShas)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFolderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan repoFolderResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state repoFolderResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var tracked map[string]string
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &tracked, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.sync(ctx, &plan, tracked, "Update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoFolderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repoFolderResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var files map[string]string
+	resp.Diagnostics.Append(state.Files.ElementsAs(ctx, &files, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only delete the files that are still there, a commit deleting a missing
+	// file is rejected.
+	remote, err := r.remoteFiles(state, state.Branch.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"error deleting repo folder",
+			err.Error(),
+		)
+		return
+	}
+
+	var deletes []string
+	for filePath := range files {
+		if _, ok := remote[filePath]; ok {
+			deletes = append(deletes, filePath)
+		}
+	}
+	if len(deletes) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	sort.Strings(deletes)
+
+	_, err = r.hub.CreateCommit(state.RepoType.ValueString(), state.RepoID.ValueString(), state.Branch.ValueString(), "Delete "+state.folderName(), nil, deletes)
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting repo folder",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchAny(t *testing.T) {
+	tests := map[string]struct {
+		patterns []string
+		relPath  string
+		want     bool
+	}{
+		"no patterns": {
+			relPath: "config.json",
+		},
+		"file name at the root": {
+			patterns: []string{"*.json"},
+			relPath:  "config.json",
+			want:     true,
+		},
+		"file name at any depth": {
+			patterns: []string{"*.bin"},
+			relPath:  "checkpoints/step-100/pytorch_model.bin",
+			want:     true,
+		},
+		"relative path": {
+			patterns: []string{"onnx/*"},
+			relPath:  "onnx/model.onnx",
+			want:     true,
+		},
+		"relative path one level too deep": {
+			patterns: []string{"onnx/*"},
+			relPath:  "onnx/quantized/model.onnx",
+		},
+		"directory name only": {
+			patterns: []string{"onnx"},
+			relPath:  "onnx/model.onnx",
+		},
+		"second pattern": {
+			patterns: []string{"*.bin", "*.safetensors"},
+			relPath:  "model.safetensors",
+			want:     true,
+		},
+		"no match": {
+			patterns: []string{"*.bin", "*.safetensors"},
+			relPath:  "README.md",
+		},
+		"malformed pattern": {
+			patterns: []string{"[", "README.md"},
+			relPath:  "README.md",
+			want:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := matchAny(test.patterns, test.relPath); got != test.want {
+				t.Errorf("matchAny(%q, %q) = %t, want %t", test.patterns, test.relPath, got, test.want)
+			}
+		})
+	}
+}
+
+func TestFolderChanges(t *testing.T) {
+	lfs := func(filePath string, sha256 string) *HubCommitAdd {
+		return &HubCommitAdd{Path: filePath, Sha256: sha256}
+	}
+	regular := func(filePath string, blobSha string) *HubCommitAdd {
+		return &HubCommitAdd{Path: filePath, BlobSha: blobSha}
+	}
+
+	tests := map[string]struct {
+		local       []*HubCommitAdd
+		remote      []HubPathInfo
+		tracked     map[string]string
+		wantAdds    []string
+		wantDeletes []string
+	}{
+		"in sync": {
+			local: []*HubCommitAdd{regular("models/config.json", "blob1"), lfs("models/model.bin", "sha1")},
+			remote: []HubPathInfo{
+				{Path: "models/config.json", Oid: "blob1"},
+				{Path: "models/model.bin", Oid: "blob2", Lfs: &HubPathInfoLfs{Oid: "sha1"}},
+			},
+			tracked: map[string]string{"models/config.json": "sha0", "models/model.bin": "sha1"},
+		},
+		"first apply": {
+			local:    []*HubCommitAdd{regular("models/config.json", "blob1"), lfs("models/model.bin", "sha1")},
+			wantAdds: []string{"models/config.json", "models/model.bin"},
+		},
+		"changed files": {
+			local: []*HubCommitAdd{regular("models/config.json", "blob2"), lfs("models/model.bin", "sha2")},
+			remote: []HubPathInfo{
+				{Path: "models/config.json", Oid: "blob1"},
+				{Path: "models/model.bin", Oid: "blob3", Lfs: &HubPathInfoLfs{Oid: "sha1"}},
+			},
+			tracked:  map[string]string{"models/config.json": "sha0", "models/model.bin": "sha1"},
+			wantAdds: []string{"models/config.json", "models/model.bin"},
+		},
+		"file removed from source_dir or excluded": {
+			local: []*HubCommitAdd{regular("models/config.json", "blob1")},
+			remote: []HubPathInfo{
+				{Path: "models/config.json", Oid: "blob1"},
+				{Path: "models/model.bin", Oid: "blob2", Lfs: &HubPathInfoLfs{Oid: "sha1"}},
+			},
+			tracked:     map[string]string{"models/config.json": "sha0", "models/model.bin": "sha1"},
+			wantDeletes: []string{"models/model.bin"},
+		},
+		"tracked file already gone from the branch": {
+			local:   []*HubCommitAdd{regular("models/config.json", "blob1")},
+			remote:  []HubPathInfo{{Path: "models/config.json", Oid: "blob1"}},
+			tracked: map[string]string{"models/config.json": "sha0", "models/model.bin": "sha1"},
+		},
+		"untracked remote file": {
+			local: []*HubCommitAdd{regular("models/config.json", "blob1")},
+			remote: []HubPathInfo{
+				{Path: "models/config.json", Oid: "blob1"},
+				{Path: "models/notes.md", Oid: "blob4"},
+			},
+			tracked: map[string]string{"models/config.json": "sha0"},
+		},
+		"adds and deletes sorted": {
+			local: []*HubCommitAdd{regular("models/c.json", "blob3"), regular("models/a.json", "blob1")},
+			remote: []HubPathInfo{
+				{Path: "models/z.json", Oid: "blob9"},
+				{Path: "models/y.json", Oid: "blob8"},
+			},
+			tracked:     map[string]string{"models/z.json": "sha9", "models/y.json": "sha8"},
+			wantAdds:    []string{"models/a.json", "models/c.json"},
+			wantDeletes: []string{"models/y.json", "models/z.json"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			local := make(map[string]*HubCommitAdd, len(test.local))
+			for _, add := range test.local {
+				local[add.Path] = add
+			}
+			remote := make(map[string]HubPathInfo, len(test.remote))
+			for _, info := range test.remote {
+				remote[info.Path] = info
+			}
+
+			adds, deletes := folderChanges(local, remote, test.tracked)
+
+			var addPaths []string
+			for _, add := range adds {
+				addPaths = append(addPaths, add.Path)
+			}
+			if !reflect.DeepEqual(addPaths, test.wantAdds) {
+				t.Errorf("adds = %q, want %q", addPaths, test.wantAdds)
+			}
+			if !reflect.DeepEqual(deletes, test.wantDeletes) {
+				t.Errorf("deletes = %q, want %q", deletes, test.wantDeletes)
+			}
+		})
+	}
+}
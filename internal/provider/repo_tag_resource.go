@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &repoTagResource{}
+	_ resource.ResourceWithConfigure   = &repoTagResource{}
+	_ resource.ResourceWithImportState = &repoTagResource{}
+	_ resource.ResourceWithModifyPlan  = &repoTagResource{}
+)
+
+func NewRepoTagResource() resource.Resource {
+	return &repoTagResource{}
+}
+
+type repoTagResource struct {
+	hub *hubClient
+}
+
+type repoTagResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	RepoID          types.String `tfsdk:"repo_id"`
+	RepoType        types.String `tfsdk:"repo_type"`
+	Name            types.String `tfsdk:"name"`
+	Revision        types.String `tfsdk:"revision"`
+	Message         types.String `tfsdk:"message"`
+	CommitSha       types.String `tfsdk:"commit_sha"`
+	TargetCommitSha types.String `tfsdk:"target_commit_sha"`
+}
+
+func (r *repoTagResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repoTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repo_tag"
+}
+
+func (r *repoTagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a tag of a repository pointing at a revision. Tags make promotions reviewable: tag a vetted commit and deploy the tag.\n\nA tag that was moved outside Terraform no longer marks the commit that was reviewed, so the next plan replaces it, recreating it at `revision`.\n\nImported tags remember that they were imported, so setting or changing `revision` or `message` in their configuration updates the state instead of replacing the tag.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The tag ID (\"namespace/name:tag\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"repo_id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\"). Changing it replaces the tag.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repo_type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Changing it replaces the tag.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The tag name. Changing it replaces the tag.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"revision": schema.StringAttribute{
+				MarkdownDescription: "The branch, tag or commit to tag. Changing it replaces the tag.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"message": schema.StringAttribute{
+				MarkdownDescription: "The tag message. Changing it replaces the tag.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					requiresReplaceUnlessImported(),
+				},
+			},
+			"commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The commit the tag was created at. An imported tag takes the commit it points at when it is first read.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"target_commit_sha": schema.StringAttribute{
+				MarkdownDescription: "The commit the tag points at on the Hub. It differs from `commit_sha` when the tag was moved outside Terraform.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *repoTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repoTagResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := plan.RepoType.ValueString()
+	repoID := plan.RepoID.ValueString()
+	err := r.hub.CreateTag(repoType, repoID, plan.Revision.ValueString(), HubCreateTagRequest{
+		Tag:     plan.Name.ValueString(),
+		Message: plan.Message.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating repo tag",
+			err.Error(),
+		)
+		return
+	}
+
+	refs, err := r.hub.GetRepoRefs(repoType, repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading repo tag",
+			"could not read refs of "+repoID+": "+err.Error(),
+		)
+		return
+	}
+	tag, ok := findGitRef(refs.Tags, plan.Name.ValueString())
+	if !ok {
+		resp.Diagnostics.AddError(
+			"error reading repo tag",
+			"tag "+plan.Name.ValueString()+" is missing from "+repoID+" after creation",
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(repoRefID(repoID, plan.Name.ValueString()))
+	plan.CommitSha = types.StringValue(tag.TargetCommit)
+	plan.TargetCommitSha = types.StringValue(tag.TargetCommit)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repoTagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refs, err := r.hub.GetRepoRefs(state.RepoType.ValueString(), state.RepoID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repo tag",
+				"could not read refs of "+state.RepoID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tag, ok := findGitRef(refs.Tags, state.Name.ValueString())
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// commit_sha keeps the commit that was reviewed, so that ModifyPlan can
+	// tell when the tag was moved outside Terraform. An imported tag has
+	// none yet and adopts its current target.
+	if state.CommitSha.IsNull() {
+		state.CommitSha = types.StringValue(tag.TargetCommit)
+	}
+	state.TargetCommitSha = types.StringValue(tag.TargetCommit)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// ModifyPlan replaces a tag that was moved outside Terraform. It no longer
+// marks the commit that was reviewed, and tags can't be moved in place.
+func (r *repoTagResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state repoTagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if state.TargetCommitSha.IsNull() || state.TargetCommitSha.Equal(state.CommitSha) {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"repo tag moved outside of terraform",
+		fmt.Sprintf("tag %s of %s points at %s instead of %s, so it will be recreated at %s", state.Name.ValueString(), state.RepoID.ValueString(), state.TargetCommitSha.ValueString(), state.CommitSha.ValueString(), state.Revision.ValueString()),
+	)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("commit_sha"), types.StringUnknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("target_commit_sha"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("commit_sha"))
+}
+
+func (r *repoTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Only the revision and message of an imported tag can change in place,
+	// and neither moves the tag.
+	var plan repoTagResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repoTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repoTagResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteTag(state.RepoType.ValueString(), state.RepoID.ValueString(), state.Name.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting repo tag",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *repoTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, name, err := parseRepoChildImportID(req.ID, "tag")
+	if err != nil {
+		resp.Diagnostics.AddError("invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoRefID(repoID, name))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("repo_type"), repoType)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	markImported(ctx, resp)
+}
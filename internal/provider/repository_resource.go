@@ -0,0 +1,414 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &repositoryResource{}
+	_ resource.ResourceWithConfigure   = &repositoryResource{}
+	_ resource.ResourceWithImportState = &repositoryResource{}
+)
+
+var repoTypes = []string{"model", "dataset", "space"}
+
+// defaultSpaceSdk is used when a Space is created through
+// huggingface_repository, which has no way to configure the SDK.
+const defaultSpaceSdk = "static"
+
+func NewRepositoryResource() resource.Resource {
+	return &repositoryResource{}
+}
+
+type repositoryResource struct {
+	hub *hubClient
+}
+
+type repositoryResourceModel struct {
+	ID                  types.String `tfsdk:"id"`
+	Type                types.String `tfsdk:"type"`
+	Name                types.String `tfsdk:"name"`
+	Namespace           types.String `tfsdk:"namespace"`
+	Private             types.Bool   `tfsdk:"private"`
+	Gated               types.String `tfsdk:"gated"`
+	DiscussionsDisabled types.Bool   `tfsdk:"discussions_disabled"`
+	URL                 types.String `tfsdk:"url"`
+}
+
+func (r *repositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *repositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repository"
+}
+
+func (r *repositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a model, dataset or Space repository on the Hugging Face Hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The repository ID (\"namespace/name\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "The repository type: \"model\" (default), \"dataset\" or \"space\". Spaces are created with the static SDK. Changing it replaces the repository.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("model"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(repoTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The repository name. Changing it replaces the repository.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The owning user or organization. Defaults to the provider `namespace`. Changing it replaces the repository.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the repository is private. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"gated": schema.StringAttribute{
+				MarkdownDescription: "Access request mode: \"auto\", \"manual\" or \"off\" (default).",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("off"),
+				Validators: []validator.String{
+					stringvalidator.OneOf("auto", "manual", "off"),
+				},
+			},
+			"discussions_disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether discussions are disabled. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The repository URL on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// splitRepoID splits a "namespace/name" repo id into its two parts.
+func splitRepoID(repoID string) (string, string, error) {
+	parts := strings.Split(repoID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("expected a repo id of the form namespace/name, got %q", repoID)
+	}
+	return parts[0], parts[1], nil
+}
+
+// repoURL returns the Hub web URL of a repo, which for datasets and Spaces
+// carries the repo type as a prefix.
+func (c *hubClient) repoURL(repoType string, repoID string) string {
+	if repoType == "model" || repoType == "" {
+		return c.HostURL + "/" + repoID
+	}
+	return c.HostURL + "/" + repoTypePath(repoType) + "/" + repoID
+}
+
+// gatedSetting converts the gated attribute into the value the settings API
+// expects, which is false rather than "off" for open repos.
+func gatedSetting(gated string) interface{} {
+	if gated == "off" {
+		return false
+	}
+	return gated
+}
+
+func repositorySettings(plan repositoryResourceModel) HubRepoSettings {
+	return HubRepoSettings{
+		Private:             plan.Private.ValueBoolPointer(),
+		Gated:               gatedSetting(plan.Gated.ValueString()),
+		DiscussionsDisabled: plan.DiscussionsDisabled.ValueBoolPointer(),
+	}
+}
+
+func (r *repositoryResource) hubRepoToRepositoryResource(repoType string, repo HubRepo, prior repositoryResourceModel) (repositoryResourceModel, error) {
+	namespace, name, err := splitRepoID(repo.ID)
+	if err != nil {
+		return repositoryResourceModel{}, err
+	}
+
+	// Not every repo type reports discussionsDisabled, in which case the
+	// last known value is kept rather than reporting drift.
+	discussionsDisabled := prior.DiscussionsDisabled
+	if repo.DiscussionsDisabled != nil {
+		discussionsDisabled = types.BoolValue(*repo.DiscussionsDisabled)
+	}
+	if discussionsDisabled.IsNull() || discussionsDisabled.IsUnknown() {
+		discussionsDisabled = types.BoolValue(false)
+	}
+
+	return repositoryResourceModel{
+		ID:                  types.StringValue(repo.ID),
+		Type:                types.StringValue(repoType),
+		Name:                types.StringValue(name),
+		Namespace:           types.StringValue(namespace),
+		Private:             types.BoolValue(repo.Private),
+		Gated:               types.StringValue(repo.GatedMode()),
+		DiscussionsDisabled: discussionsDisabled,
+		URL:                 types.StringValue(r.hub.repoURL(repoType, repo.ID)),
+	}, nil
+}
+
+func (r *repositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan repositoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := plan.Namespace.ValueString()
+	if plan.Namespace.IsUnknown() || plan.Namespace.IsNull() || namespace == "" {
+		namespace = r.hub.Namespace
+	}
+	repoType := plan.Type.ValueString()
+	repoID := namespace + "/" + plan.Name.ValueString()
+
+	createRepoRequest := HubCreateRepoRequest{
+		Name:         plan.Name.ValueString(),
+		Organization: namespace,
+		Type:         repoTypeParam(repoType),
+		Private:      plan.Private.ValueBool(),
+	}
+	if repoType == "space" {
+		createRepoRequest.Sdk = defaultSpaceSdk
+	}
+
+	_, err := r.hub.CreateRepo(createRepoRequest)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating repository",
+			err.Error(),
+		)
+		return
+	}
+
+	if plan.Gated.ValueString() != "off" || plan.DiscussionsDisabled.ValueBool() {
+		err = r.hub.UpdateRepoSettings(repoType, repoID, repositorySettings(plan))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating repository settings",
+				"repository "+repoID+" was created but its settings could not be applied: "+err.Error(),
+			)
+
+			// Keep track of the repository that was created, with the settings
+			// it was created with, so the next apply retries the settings
+			// instead of creating it again.
+			plan.ID = types.StringValue(repoID)
+			plan.Namespace = types.StringValue(namespace)
+			plan.Gated = types.StringValue("off")
+			plan.DiscussionsDisabled = types.BoolValue(false)
+			plan.URL = types.StringValue(r.hub.repoURL(repoType, repoID))
+			diags = resp.State.Set(ctx, plan)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+
+	repo, err := r.hub.GetRepo(repoType, repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading repository",
+			"could not read repository "+repoID+": "+err.Error(),
+		)
+		return
+	}
+
+	state, err := r.hubRepoToRepositoryResource(repoType, repo, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("error reading repository", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state repositoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repo, err := r.hub.GetRepo(state.Type.ValueString(), state.ID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading repository",
+				"could not read repository "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	newState, err := r.hubRepoToRepositoryResource(state.Type.ValueString(), repo, state)
+	if err != nil {
+		resp.Diagnostics.AddError("error reading repository", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan repositoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoType := plan.Type.ValueString()
+	repoID := plan.ID.ValueString()
+
+	err := r.hub.UpdateRepoSettings(repoType, repoID, repositorySettings(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating repository",
+			err.Error(),
+		)
+		return
+	}
+
+	repo, err := r.hub.GetRepo(repoType, repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading repository",
+			"could not read repository "+repoID+": "+err.Error(),
+		)
+		return
+	}
+
+	state, err := r.hubRepoToRepositoryResource(repoType, repo, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("error reading repository", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *repositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state repositoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteRepo(HubDeleteRepoRequest{
+		Name:         state.Name.ValueString(),
+		Organization: state.Namespace.ValueString(),
+		Type:         repoTypeParam(state.Type.ValueString()),
+	})
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting repository",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+// parseRepoImportID accepts "namespace/name" for models and
+// "type/namespace/name" for any repo type.
+func parseRepoImportID(importID string) (string, string, error) {
+	parts := strings.Split(importID, "/")
+	switch len(parts) {
+	case 2:
+		return "model", importID, nil
+	case 3:
+		for _, repoType := range repoTypes {
+			if parts[0] == repoType {
+				return repoType, parts[1] + "/" + parts[2], nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("expected an import id of the form namespace/name or type/namespace/name, got %q", importID)
+}
+
+func (r *repositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	repoType, repoID, err := parseRepoImportID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("invalid import id", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), repoID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), repoType)...)
+}
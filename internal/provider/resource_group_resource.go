@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &resourceGroupResource{}
+	_ resource.ResourceWithConfigure   = &resourceGroupResource{}
+	_ resource.ResourceWithImportState = &resourceGroupResource{}
+)
+
+func NewResourceGroupResource() resource.Resource {
+	return &resourceGroupResource{}
+}
+
+type resourceGroupResource struct {
+	hub *hubClient
+}
+
+type resourceGroupResourceModel struct {
+	ID           types.String          `tfsdk:"id"`
+	Organization types.String          `tfsdk:"organization"`
+	Name         types.String          `tfsdk:"name"`
+	Description  types.String          `tfsdk:"description"`
+	Members      []ResourceGroupMember `tfsdk:"members"`
+}
+
+type ResourceGroupMember struct {
+	User string `tfsdk:"user"`
+	Role string `tfsdk:"role"`
+}
+
+func (r *resourceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *resourceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_resource_group"
+}
+
+func (r *resourceGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a resource group in an organization, which restricts access to the repositories in it to the group's members.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The resource group ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"organization": schema.StringAttribute{
+				MarkdownDescription: "The organization name. Changing it replaces the resource group.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The resource group name.",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "The resource group description.",
+				Optional:            true,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "The members of the resource group. When omitted, members are not managed.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user": schema.StringAttribute{
+							MarkdownDescription: "The username.",
+							Required:            true,
+						},
+						"role": schema.StringAttribute{
+							MarkdownDescription: "The role: \"read\", \"contributor\", \"write\" or \"admin\".",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(organizationRoles...),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// syncResourceGroupMembers adds, re-roles and removes members so that the
+// group matches members. Members already in the right role are not touched.
+func (r *resourceGroupResource) syncResourceGroupMembers(organization string, id string, current []HubResourceGroupMember, members []ResourceGroupMember) error {
+	roles := make(map[string]string, len(current))
+	for _, member := range current {
+		roles[member.User] = member.Role
+	}
+
+	wanted := make(map[string]bool, len(members))
+	var added []HubResourceGroupMember
+	for _, member := range members {
+		wanted[member.User] = true
+		role, ok := roles[member.User]
+		if !ok {
+			added = append(added, HubResourceGroupMember{User: member.User, Role: member.Role})
+			continue
+		}
+		if role != member.Role {
+			err := r.hub.SetResourceGroupMemberRole(organization, id, member.User, member.Role)
+			if err != nil {
+				return fmt.Errorf("could not change the role of %s: %w", member.User, err)
+			}
+		}
+	}
+
+	for _, member := range current {
+		if wanted[member.User] {
+			continue
+		}
+		err := r.hub.RemoveResourceGroupMember(organization, id, member.User)
+		if err != nil {
+			return fmt.Errorf("could not remove %s: %w", member.User, err)
+		}
+	}
+
+	if len(added) > 0 {
+		err := r.hub.AddResourceGroupMembers(organization, id, added)
+		if err != nil {
+			return fmt.Errorf("could not add members: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func hubResourceGroupToResourceGroupResource(organization string, group HubResourceGroup, prior resourceGroupResourceModel) resourceGroupResourceModel {
+	state := resourceGroupResourceModel{
+		ID:           types.StringValue(group.ID),
+		Organization: types.StringValue(organization),
+		Name:         types.StringValue(group.Name),
+	}
+
+	// The Hub reports a missing description as an empty string.
+	if group.Description != "" || !prior.Description.IsNull() {
+		state.Description = types.StringValue(group.Description)
+	}
+
+	// Without members in the configuration, the members aren't managed.
+	if prior.Members == nil {
+		return state
+	}
+
+	// Members are listed in the configured order, followed by members added
+	// outside Terraform, so that reordering the configuration isn't drift.
+	remaining := make(map[string]string, len(group.Users))
+	for _, member := range group.Users {
+		remaining[member.User] = member.Role
+	}
+	for _, member := range prior.Members {
+		if role, ok := remaining[member.User]; ok {
+			state.Members = append(state.Members, ResourceGroupMember{User: member.User, Role: role})
+			delete(remaining, member.User)
+		}
+	}
+	for _, member := range group.Users {
+		if _, ok := remaining[member.User]; ok {
+			state.Members = append(state.Members, ResourceGroupMember{User: member.User, Role: member.Role})
+		}
+	}
+	if state.Members == nil {
+		state.Members = []ResourceGroupMember{}
+	}
+
+	return state
+}
+
+func (r *resourceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization := plan.Organization.ValueString()
+	request := HubResourceGroupRequest{
+		Name:        plan.Name.ValueStringPointer(),
+		Description: plan.Description.ValueStringPointer(),
+	}
+	for _, member := range plan.Members {
+		request.Users = append(request.Users, HubResourceGroupMember{User: member.User, Role: member.Role})
+	}
+
+	group, err := r.hub.CreateResourceGroup(organization, request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating resource group",
+			err.Error(),
+		)
+		return
+	}
+
+	// The creator may be added to the group automatically, so the group is
+	// read back and reconciled with the configured members.
+	id := group.ID
+	group, err = r.hub.GetResourceGroup(organization, id)
+	if err == nil && plan.Members != nil {
+		err = r.syncResourceGroupMembers(organization, id, group.Users, plan.Members)
+		if err == nil {
+			group, err = r.hub.GetResourceGroup(organization, id)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating resource group members",
+			"resource group "+id+" was created but its members could not be set: "+err.Error(),
+		)
+		return
+	}
+
+	state := hubResourceGroupToResourceGroupResource(organization, group, plan)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.hub.GetResourceGroup(state.Organization.ValueString(), state.ID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading resource group",
+				"could not read resource group "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	newState := hubResourceGroupToResourceGroupResource(state.Organization.ValueString(), group, state)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceGroupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization := state.Organization.ValueString()
+	id := state.ID.ValueString()
+	description := plan.Description.ValueString()
+	err := r.hub.UpdateResourceGroup(organization, id, HubResourceGroupRequest{
+		Name:        plan.Name.ValueStringPointer(),
+		Description: &description,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating resource group",
+			err.Error(),
+		)
+		return
+	}
+
+	group, err := r.hub.GetResourceGroup(organization, id)
+	if err == nil && plan.Members != nil {
+		err = r.syncResourceGroupMembers(organization, id, group.Users, plan.Members)
+		if err == nil {
+			group, err = r.hub.GetResourceGroup(organization, id)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating resource group members",
+			err.Error(),
+		)
+		return
+	}
+
+	newState := hubResourceGroupToResourceGroupResource(organization, group, plan)
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *resourceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceGroupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteResourceGroup(state.Organization.ValueString(), state.ID.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting resource group",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *resourceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	organization, id, err := splitRepoID(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"invalid import id",
+			fmt.Sprintf("expected an import id of the form organization/resource-group-id, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("organization"), organization)...)
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestHubResourceGroupToResourceGroupResourceMembers(t *testing.T) {
+	member := func(user string, role string) ResourceGroupMember {
+		return ResourceGroupMember{User: user, Role: role}
+	}
+	hubMember := func(user string, role string) HubResourceGroupMember {
+		return HubResourceGroupMember{User: user, Role: role}
+	}
+
+	tests := map[string]struct {
+		prior []ResourceGroupMember
+		users []HubResourceGroupMember
+		want  []ResourceGroupMember
+	}{
+		"members not managed": {
+			users: []HubResourceGroupMember{hubMember("alice", "admin")},
+		},
+		"configured order kept": {
+			prior: []ResourceGroupMember{member("carol", "read"), member("alice", "admin"), member("bob", "write")},
+			users: []HubResourceGroupMember{hubMember("alice", "admin"), hubMember("bob", "write"), hubMember("carol", "read")},
+			want:  []ResourceGroupMember{member("carol", "read"), member("alice", "admin"), member("bob", "write")},
+		},
+		"members added outside terraform last": {
+			prior: []ResourceGroupMember{member("bob", "write")},
+			users: []HubResourceGroupMember{hubMember("dave", "read"), hubMember("bob", "write"), hubMember("alice", "admin")},
+			want:  []ResourceGroupMember{member("bob", "write"), member("dave", "read"), member("alice", "admin")},
+		},
+		"members removed outside terraform dropped": {
+			prior: []ResourceGroupMember{member("alice", "admin"), member("bob", "write")},
+			users: []HubResourceGroupMember{hubMember("bob", "write")},
+			want:  []ResourceGroupMember{member("bob", "write")},
+		},
+		"role from the hub": {
+			prior: []ResourceGroupMember{member("alice", "read")},
+			users: []HubResourceGroupMember{hubMember("alice", "admin")},
+			want:  []ResourceGroupMember{member("alice", "admin")},
+		},
+		"no members left": {
+			prior: []ResourceGroupMember{member("alice", "admin")},
+			want:  []ResourceGroupMember{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			prior := resourceGroupResourceModel{Description: types.StringNull(), Members: test.prior}
+			group := HubResourceGroup{ID: "0123", Name: "research", Users: test.users}
+
+			state := hubResourceGroupToResourceGroupResource("acme", group, prior)
+
+			if !reflect.DeepEqual(state.Members, test.want) {
+				t.Errorf("members = %v, want %v", state.Members, test.want)
+			}
+		})
+	}
+}
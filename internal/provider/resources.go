@@ -4,65 +4,184 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/issamemari/huggingface-endpoints-client-go"
 )
 
 var (
-	_ resource.Resource              = &endpointResource{}
-	_ resource.ResourceWithConfigure = &endpointResource{}
+	_ resource.Resource                     = &endpointResource{}
+	_ resource.ResourceWithConfigure        = &endpointResource{}
+	_ resource.ResourceWithModifyPlan       = &endpointResource{}
+	_ resource.ResourceWithValidateConfig   = &endpointResource{}
+	_ resource.ResourceWithConfigValidators = &endpointResource{}
 )
 
+// on_destroy values, deciding what happens to the endpoint when the resource
+// is destroyed.
+const (
+	onDestroyDelete      = "delete"
+	onDestroyPause       = "pause"
+	onDestroyScaleToZero = "scale_to_zero"
+	onDestroyAbandon     = "abandon"
+)
+
+// defaultEndpointTimeout bounds how long operations wait for an endpoint to
+// reach the state they expect.
+const defaultEndpointTimeout = 30 * time.Minute
+
 func NewEndpointResource() resource.Resource {
 	return &endpointResource{}
 }
 
 type endpointResource struct {
 	client *huggingface.Client
+	hub    *hubClient
 }
 
 type endpointResourceModel struct {
-	AccountId types.String `tfsdk:"account_id"`
-	Compute   Compute      `tfsdk:"compute"`
-	Model     Model        `tfsdk:"model"`
-	Name      types.String `tfsdk:"name"`
-	Cloud     Cloud        `tfsdk:"cloud"`
-	Type      types.String `tfsdk:"type"`
+	AccountId          types.String    `tfsdk:"account_id"`
+	Compute            Compute         `tfsdk:"compute"`
+	Model              Model           `tfsdk:"model"`
+	Name               types.String    `tfsdk:"name"`
+	NamePrefix         types.String    `tfsdk:"name_prefix"`
+	Cloud              Cloud           `tfsdk:"cloud"`
+	Type               types.String    `tfsdk:"type"`
+	PrivateService     *PrivateService `tfsdk:"private_service"`
+	Status             types.Object    `tfsdk:"status"`
+	HubValidation      types.String    `tfsdk:"hub_validation"`
+	DeletionProtection types.Bool      `tfsdk:"deletion_protection"`
+	RollbackOnFailure  types.Bool      `tfsdk:"rollback_on_failure"`
+	OnDestroy          types.String    `tfsdk:"on_destroy"`
+	Timeouts           timeouts.Value  `tfsdk:"timeouts"`
+}
+
+var endpointUserAttributeTypes = map[string]attr.Type{
+	"id":   types.StringType,
+	"name": types.StringType,
+}
+
+var endpointPrivateStatusAttributeTypes = map[string]attr.Type{
+	"service_name": types.StringType,
+}
+
+var endpointStatusAttributeTypes = map[string]attr.Type{
+	"created_at":     types.StringType,
+	"created_by":     types.ObjectType{AttrTypes: endpointUserAttributeTypes},
+	"error_message":  types.StringType,
+	"message":        types.StringType,
+	"private":        types.ObjectType{AttrTypes: endpointPrivateStatusAttributeTypes},
+	"ready_replica":  types.Int64Type,
+	"state":          types.StringType,
+	"target_replica": types.Int64Type,
+	"updated_at":     types.StringType,
+	"updated_by":     types.ObjectType{AttrTypes: endpointUserAttributeTypes},
+	"url":            types.StringType,
+}
+
+// copyProviderOnlyAttributes carries over the attributes that only exist in
+// Terraform and are therefore missing from API responses.
+func (m *endpointResourceModel) copyProviderOnlyAttributes(from endpointResourceModel) {
+	m.NamePrefix = from.NamePrefix
+	m.HubValidation = from.HubValidation
+	if m.HubValidation.IsNull() || m.HubValidation.IsUnknown() {
+		m.HubValidation = types.StringValue(hubValidationOff)
+	}
+	m.DeletionProtection = from.DeletionProtection
+	if m.DeletionProtection.IsNull() || m.DeletionProtection.IsUnknown() {
+		m.DeletionProtection = types.BoolValue(false)
+	}
+	m.RollbackOnFailure = from.RollbackOnFailure
+	if m.RollbackOnFailure.IsNull() || m.RollbackOnFailure.IsUnknown() {
+		m.RollbackOnFailure = types.BoolValue(false)
+	}
+	m.OnDestroy = from.OnDestroy
+	if m.OnDestroy.IsNull() || m.OnDestroy.IsUnknown() {
+		m.OnDestroy = types.StringValue(onDestroyDelete)
+	}
+	m.Timeouts = from.Timeouts
+
+	// The API doesn't echo the private service configuration back on every
+	// response, so keep the configured one when it's missing.
+	if m.PrivateService == nil {
+		m.PrivateService = from.PrivateService
+	}
+
+	// The API only knows disable_custom_kernels, so an endpoint configured
+	// with the deprecated diable_custom_kernels keeps the value under that
+	// name.
+	if tgi, fromTgi := m.Model.Image.Tgi, from.Model.Image.Tgi; tgi != nil && fromTgi != nil &&
+		fromTgi.DiableCustomKernels != nil && fromTgi.DisableCustomKernels == nil {
+		tgi.DiableCustomKernels = tgi.DisableCustomKernels
+		tgi.DisableCustomKernels = nil
+	}
+}
+
+// keepConfiguredCase keeps the configured spelling of the values the API
+// matches case-insensitively and returns lowercased, so that e.g. type =
+// "Private" isn't reported as drift against "private".
+func (m *endpointResourceModel) keepConfiguredCase(from endpointResourceModel) {
+	if strings.EqualFold(m.Type.ValueString(), from.Type.ValueString()) {
+		m.Type = from.Type
+	}
+	if strings.EqualFold(m.Compute.Accelerator, from.Compute.Accelerator) {
+		m.Compute.Accelerator = from.Compute.Accelerator
+	}
+	if strings.EqualFold(m.Model.Framework, from.Model.Framework) {
+		m.Model.Framework = from.Model.Framework
+	}
 }
 
 func (r *endpointResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	client, ok := req.ProviderData.(*huggingface.Client)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"unexpected data source configure type",
-			fmt.Sprintf("expected *huggingface.Client, got: %T.", req.ProviderData),
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
 		)
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.hub = data.hub
 }
 
 func (r *endpointResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_endpoint"
 }
 
-func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *endpointResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"account_id": schema.StringAttribute{
-				Optional: true,
+				Optional:           true,
+				DeprecationMessage: "Use private_service.account_id instead.",
 			},
 			"compute": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"accelerator": schema.StringAttribute{
 						Required: true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(endpointAccelerators...),
+						},
 					},
 					"instance_size": schema.StringAttribute{
 						Required: true,
@@ -103,6 +222,9 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Attributes: map[string]schema.Attribute{
 					"framework": schema.StringAttribute{
 						Required: true,
+						Validators: []validator.String{
+							stringvalidator.OneOfCaseInsensitive(endpointFrameworks...),
+						},
 					},
 					"env": schema.MapAttribute{
 						Optional:    true,
@@ -161,6 +283,9 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 									},
 									"pooling": schema.StringAttribute{
 										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(teiPoolingOptions...),
+										},
 									},
 								},
 							},
@@ -189,11 +314,21 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 									"max_total_tokens": schema.Int64Attribute{
 										Optional: true,
 									},
-									"diable_custom_kernels": schema.BoolAttribute{
+									"disable_custom_kernels": schema.BoolAttribute{
 										Optional: true,
 									},
+									"diable_custom_kernels": schema.BoolAttribute{
+										Optional:           true,
+										DeprecationMessage: "Use disable_custom_kernels instead.",
+										Validators: []validator.Bool{
+											boolvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("disable_custom_kernels")),
+										},
+									},
 									"quantize": schema.StringAttribute{
 										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(tgiQuantizeOptions...),
+										},
 									},
 								},
 							},
@@ -224,6 +359,9 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 									},
 									"hf_auto_cast_type": schema.StringAttribute{
 										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(tgiNeuronAutoCastTypes...),
+										},
 									},
 									"hf_num_cores": schema.Int64Attribute{
 										Optional: true,
@@ -275,6 +413,9 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 									},
 									"kv_cache_dtype": schema.StringAttribute{
 										Optional: true,
+										Validators: []validator.String{
+											stringvalidator.OneOf(vllmKvCacheDtypes...),
+										},
 									},
 									"max_num_batched_tokens": schema.Int64Attribute{
 										Optional: true,
@@ -302,27 +443,397 @@ func (r *endpointResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				},
 			},
 			"name": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name_prefix")),
+					stringvalidator.LengthAtMost(endpointNameMaxLength),
+					stringvalidator.RegexMatches(endpointNamePattern, "must only contain lowercase letters, digits and dashes, and must not start or end with a dash"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(endpointNameMaxLength - endpointNameSuffixLength),
+					stringvalidator.RegexMatches(endpointNamePrefixPattern, "must only contain lowercase letters, digits and dashes, and must not start with a dash"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"cloud": schema.SingleNestedAttribute{
 				Required: true,
 				Attributes: map[string]schema.Attribute{
 					"region": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 					"vendor": schema.StringAttribute{
 						Required: true,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.RequiresReplace(),
+						},
 					},
 				},
 			},
 			"type": schema.StringAttribute{
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(endpointTypes...),
+				},
+			},
+			"private_service": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"account_id": schema.StringAttribute{
+						Required: true,
+					},
+					"shared": schema.BoolAttribute{
+						Optional: true,
+						Computed: true,
+						Default:  booldefault.StaticBool(false),
+					},
+				},
 			},
+			"status": schema.SingleNestedAttribute{
+				Computed: true,
+				Attributes: map[string]schema.Attribute{
+					"created_at": schema.StringAttribute{
+						Computed: true,
+					},
+					"created_by": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"id": schema.StringAttribute{
+								Computed: true,
+							},
+							"name": schema.StringAttribute{
+								Computed: true,
+							},
+						},
+					},
+					"error_message": schema.StringAttribute{
+						Computed: true,
+					},
+					"message": schema.StringAttribute{
+						Computed: true,
+					},
+					"private": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"service_name": schema.StringAttribute{
+								Computed: true,
+							},
+						},
+					},
+					"ready_replica": schema.Int64Attribute{
+						Computed: true,
+					},
+					"state": schema.StringAttribute{
+						Computed: true,
+					},
+					"target_replica": schema.Int64Attribute{
+						Computed: true,
+					},
+					"updated_at": schema.StringAttribute{
+						Computed: true,
+					},
+					"updated_by": schema.SingleNestedAttribute{
+						Computed: true,
+						Attributes: map[string]schema.Attribute{
+							"id": schema.StringAttribute{
+								Computed: true,
+							},
+							"name": schema.StringAttribute{
+								Computed: true,
+							},
+						},
+					},
+					"url": schema.StringAttribute{
+						Computed: true,
+					},
+				},
+			},
+			"hub_validation": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(hubValidationOff),
+				Validators: []validator.String{
+					stringvalidator.OneOf(hubValidationOff, hubValidationWarn, hubValidationStrict),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(onDestroyDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyDelete, onDestroyPause, onDestroyScaleToZero, onDestroyAbandon),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func clientEndpointToProviderEndpoint(endpoint huggingface.EndpointDetails) endpointResourceModel {
+func (r *endpointResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		endpointScalingValidator{},
+	}
+}
+
+func (r *endpointResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var endpointType types.String
+	var privateService types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &endpointType)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_service"), &privateService)...)
+	if resp.Diagnostics.HasError() || endpointType.IsUnknown() || endpointType.IsNull() {
+		return
+	}
+
+	if !privateService.IsNull() && !strings.EqualFold(endpointType.ValueString(), "private") {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_service"),
+			"private service requires a private endpoint",
+			"private_service can only be set when type is \"private\", got \""+endpointType.ValueString()+"\".",
+		)
+	}
+}
+
+func (r *endpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.checkDeletionProtection(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() || r.hub == nil {
+		return
+	}
+
+	var mode types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("hub_validation"), &mode)...)
+	if resp.Diagnostics.HasError() || mode.IsUnknown() || mode.IsNull() || mode.ValueString() == hubValidationOff {
+		return
+	}
+
+	// The checks read the model from the Hub and the instances from the
+	// catalog, so they only run when something they depend on changed.
+	if !req.State.Raw.IsNull() {
+		changed, diags := endpointCheckInputsChanged(ctx, req)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() || !changed {
+			return
+		}
+	}
+
+	var repository, revision, task, framework types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("repository"), &repository)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("revision"), &revision)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("task"), &task)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("framework"), &framework)...)
+	if resp.Diagnostics.HasError() || repository.IsUnknown() || revision.IsUnknown() {
+		return
+	}
+
+	var vendor, accelerator, instanceType, instanceSize, quantize types.String
+	var vllm types.Object
+	var tensorParallelSize types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cloud").AtName("vendor"), &vendor)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("compute").AtName("accelerator"), &accelerator)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("compute").AtName("instance_type"), &instanceType)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("compute").AtName("instance_size"), &instanceSize)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("image").AtName("tgi").AtName("quantize"), &quantize)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("image").AtName("vllm"), &vllm)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("model").AtName("image").AtName("vllm").AtName("tensor_parallel_size"), &tensorParallelSize)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	hardwareKnown := !vendor.IsUnknown() && !accelerator.IsUnknown() && !instanceType.IsUnknown() && !instanceSize.IsUnknown() &&
+		!quantize.IsUnknown() && !vllm.IsUnknown() && !tensorParallelSize.IsUnknown()
+
+	reporter := hubValidationReporter{mode: mode.ValueString(), diags: &resp.Diagnostics}
+	model, err := r.hub.GetModel(repository.ValueString(), revision.ValueString())
+	if err != nil {
+		reporter.report(
+			path.Root("model").AtName("repository"),
+			"unable to check model against the hub",
+			"could not read model repository "+repository.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if !task.IsUnknown() && !framework.IsUnknown() {
+		checkEndpointModelMetadata(model, task.ValueString(), framework.ValueString(), reporter)
+	}
+
+	if !hardwareKnown || r.client == nil {
+		return
+	}
+	catalog, err := getEndpointCatalog(r.client)
+	if err != nil {
+		// As with the model, a catalog that can't be read skips the check.
+		return
+	}
+
+	// The memory estimate is a heuristic, so the fit check only ever warns,
+	// even with hub_validation set to strict.
+	checkEndpointHardwareFit(model, endpointHardware{
+		Vendor:             vendor.ValueString(),
+		Accelerator:        accelerator.ValueString(),
+		InstanceType:       instanceType.ValueString(),
+		InstanceSize:       instanceSize.ValueString(),
+		Quantize:           quantize.ValueString(),
+		Vllm:               !vllm.IsNull(),
+		TensorParallelSize: tensorParallelSize.ValueInt64(),
+	}, catalog, hubValidationReporter{mode: hubValidationWarn, diags: &resp.Diagnostics})
+}
+
+// endpointCheckAttributes are the attributes the hub checks of ModifyPlan
+// depend on.
+var endpointCheckAttributes = []path.Path{
+	path.Root("hub_validation"),
+	path.Root("model"),
+	path.Root("compute"),
+	path.Root("cloud"),
+}
+
+// endpointCheckInputsChanged reports whether the plan changes any of
+// endpointCheckAttributes from the prior state.
+func endpointCheckInputsChanged(ctx context.Context, req resource.ModifyPlanRequest) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	for _, attribute := range endpointCheckAttributes {
+		var planned, prior attr.Value
+		diags.Append(req.Plan.GetAttribute(ctx, attribute, &planned)...)
+		diags.Append(req.State.GetAttribute(ctx, attribute, &prior)...)
+		if diags.HasError() {
+			return false, diags
+		}
+		if !planned.Equal(prior) {
+			return true, diags
+		}
+	}
+	return false, diags
+}
+
+func endpointUserObject(user huggingface.User) types.Object {
+	return types.ObjectValueMust(endpointUserAttributeTypes, map[string]attr.Value{
+		"id":   types.StringValue(user.ID),
+		"name": types.StringValue(user.Name),
+	})
+}
+
+func endpointStatusObject(status EndpointStatus) types.Object {
+	return types.ObjectValueMust(endpointStatusAttributeTypes, map[string]attr.Value{
+		"created_at":    types.StringValue(status.CreatedAt),
+		"created_by":    endpointUserObject(status.CreatedBy),
+		"error_message": types.StringValue(status.ErrorMessage),
+		"message":       types.StringValue(status.Message),
+		"private": types.ObjectValueMust(endpointPrivateStatusAttributeTypes, map[string]attr.Value{
+			"service_name": types.StringValue(status.Private.ServiceName),
+		}),
+		"ready_replica":  types.Int64Value(int64(status.ReadyReplica)),
+		"state":          types.StringValue(status.State),
+		"target_replica": types.Int64Value(int64(status.TargetReplica)),
+		"updated_at":     types.StringValue(status.UpdatedAt),
+		"updated_by":     endpointUserObject(status.UpdatedBy),
+		"url":            types.StringValue(status.URL),
+	})
+}
+
+// endpointReplaceAttributes are the attributes whose change replaces the
+// endpoint, which deletion_protection guards like a destroy. An endpoint
+// can't be moved to another cloud or region, so those replace it too. Other
+// changes are applied in place and aren't guarded.
+var endpointReplaceAttributes = []path.Path{
+	path.Root("name"),
+	path.Root("name_prefix"),
+	path.Root("cloud").AtName("vendor"),
+	path.Root("cloud").AtName("region"),
+}
+
+// destroyDeletesEndpoint reports whether destroying the resource deletes the
+// endpoint, which is all deletion_protection guards against. Pausing,
+// scaling to zero and abandoning leave the endpoint in place.
+func destroyDeletesEndpoint(onDestroy types.String) bool {
+	return onDestroy.IsNull() || onDestroy.IsUnknown() || onDestroy.ValueString() == onDestroyDelete
+}
+
+// checkDeletionProtection fails plans that would destroy a protected
+// endpoint, including replacements, unless on_destroy keeps the endpoint.
+// Protection and on_destroy are read from the prior state, so that changing
+// them has to be applied before the endpoint can go.
+//
+// Replacements are found by comparing the plan with the state, since the
+// replacements requested by attribute plan modifiers aren't passed on to
+// ModifyPlan.
+func (r *endpointResource) checkDeletionProtection(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var protected types.Bool
+	var onDestroy types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &protected)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)...)
+	if resp.Diagnostics.HasError() || !protected.ValueBool() || !destroyDeletesEndpoint(onDestroy) {
+		return
+	}
+
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		resp.Diagnostics.AddError(
+			"endpoint is protected from deletion",
+			"endpoint "+name.ValueString()+" has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying it.",
+		)
+		return
+	}
+
+	var changes []string
+	for _, attribute := range endpointReplaceAttributes {
+		var planned, prior types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, attribute, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, attribute, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planned.Equal(prior) {
+			changes = append(changes, attribute.String())
+		}
+	}
+	if len(changes) > 0 {
+		resp.Diagnostics.AddError(
+			"endpoint is protected from deletion",
+			"endpoint "+name.ValueString()+" has deletion_protection enabled, but changing "+strings.Join(changes, ", ")+
+				" requires replacing it. Set deletion_protection to false and apply that change before replacing it.",
+		)
+	}
+}
+
+func clientEndpointToProviderEndpoint(endpoint EndpointDetails) endpointResourceModel {
 	var image Image
 	if endpoint.Model.Image.Huggingface != nil {
 		image = Image{
@@ -478,7 +989,15 @@ func clientEndpointToProviderEndpoint(endpoint huggingface.EndpointDetails) endp
 			Region: endpoint.Provider.Region,
 			Vendor: endpoint.Provider.Vendor,
 		},
-		Type: types.StringValue(endpoint.Type),
+		Type:   types.StringValue(endpoint.Type),
+		Status: endpointStatusObject(endpoint.Status),
+	}
+
+	if endpoint.Private != nil {
+		providerEndpoint.PrivateService = &PrivateService{
+			AccountId: endpoint.Private.AccountId,
+			Shared:    endpoint.Private.Shared,
+		}
 	}
 
 	if endpoint.Model.Env == nil {
@@ -488,7 +1007,19 @@ func clientEndpointToProviderEndpoint(endpoint huggingface.EndpointDetails) endp
 	return providerEndpoint
 }
 
-func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) huggingface.CreateEndpointRequest {
+// providerEndpointPrivateService returns the private service configuration to
+// send to the API, or nil when the endpoint has none.
+func providerEndpointPrivateService(endpoint endpointResourceModel) *EndpointPrivateService {
+	if endpoint.PrivateService == nil {
+		return nil
+	}
+	return &EndpointPrivateService{
+		AccountId: endpoint.PrivateService.AccountId,
+		Shared:    endpoint.PrivateService.Shared,
+	}
+}
+
+func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) CreateEndpointRequest {
 	var image huggingface.Image
 	if endpoint.Model.Image.Huggingface != nil {
 		image = huggingface.Image{
@@ -532,7 +1063,7 @@ func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) hug
 				MaxBatchTotalTokens:   endpoint.Model.Image.Tgi.MaxBatchTotalTokens,
 				MaxInputLength:        endpoint.Model.Image.Tgi.MaxInputLength,
 				MaxTotalTokens:        endpoint.Model.Image.Tgi.MaxTotalTokens,
-				DisableCustomKernels:  endpoint.Model.Image.Tgi.DisableCustomKernels,
+				DisableCustomKernels:  endpoint.Model.Image.Tgi.disableCustomKernels(),
 				Quantize:              endpoint.Model.Image.Tgi.Quantize,
 			},
 		}
@@ -641,10 +1172,13 @@ func providerEndpointToCreateEndpointRequest(endpoint endpointResourceModel) hug
 		Type: endpoint.Type.ValueString(),
 	}
 
-	return huggingfaceEndpoint
+	return CreateEndpointRequest{
+		CreateEndpointRequest: huggingfaceEndpoint,
+		Private:               providerEndpointPrivateService(endpoint),
+	}
 }
 
-func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) huggingface.UpdateEndpointRequest {
+func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) UpdateEndpointRequest {
 	var image huggingface.Image
 	if endpoint.Model.Image.Huggingface != nil {
 		image = huggingface.Image{
@@ -688,7 +1222,7 @@ func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) hug
 				MaxBatchTotalTokens:   endpoint.Model.Image.Tgi.MaxBatchTotalTokens,
 				MaxInputLength:        endpoint.Model.Image.Tgi.MaxInputLength,
 				MaxTotalTokens:        endpoint.Model.Image.Tgi.MaxTotalTokens,
-				DisableCustomKernels:  endpoint.Model.Image.Tgi.DisableCustomKernels,
+				DisableCustomKernels:  endpoint.Model.Image.Tgi.disableCustomKernels(),
 				Quantize:              endpoint.Model.Image.Tgi.Quantize,
 			},
 		}
@@ -791,7 +1325,11 @@ func providerEndpointToUpdateEndpointRequest(endpoint endpointResourceModel) hug
 		Type: endpoint.Type.ValueStringPointer(),
 	}
 
-	return huggingfaceEndpoint
+	return UpdateEndpointRequest{
+		UpdateEndpointRequest: huggingfaceEndpoint,
+		AccountId:             endpoint.AccountId.ValueStringPointer(),
+		Private:               providerEndpointPrivateService(endpoint),
+	}
 }
 
 func (r *endpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -802,6 +1340,12 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultEndpointTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	existingEndpoints, err := r.client.ListEndpoints()
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -811,22 +1355,40 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	useUpdate := false
+	existingNames := make(map[string]bool, len(existingEndpoints))
 	for _, existingEndpoint := range existingEndpoints {
-		if existingEndpoint.Name == plan.Name.ValueString() {
-			useUpdate = true
-			break
+		existingNames[existingEndpoint.Name] = true
+	}
+
+	// A generated name must never pick up an existing endpoint, so a suffix
+	// that happens to be taken is simply drawn again.
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		for {
+			name, err := generateEndpointName(plan.NamePrefix.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"error creating endpoint",
+					err.Error(),
+				)
+				return
+			}
+			if !existingNames[name] {
+				plan.Name = types.StringValue(name)
+				break
+			}
 		}
 	}
 
-	var createdEndpoint huggingface.EndpointDetails
+	useUpdate := existingNames[plan.Name.ValueString()]
+
+	var createdEndpoint EndpointDetails
 
 	if useUpdate {
 		updateEndpointRequest := providerEndpointToUpdateEndpointRequest(plan)
-		createdEndpoint, err = r.client.UpdateEndpoint(plan.Name.ValueString(), updateEndpointRequest)
+		createdEndpoint, err = updateEndpoint(r.client, plan.Name.ValueString(), updateEndpointRequest)
 	} else {
 		createEndpointRequest := providerEndpointToCreateEndpointRequest(plan)
-		createdEndpoint, err = r.client.CreateEndpoint(createEndpointRequest)
+		createdEndpoint, err = createEndpoint(r.client, createEndpointRequest)
 	}
 
 	if err != nil {
@@ -837,7 +1399,35 @@ func (r *endpointResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	plan = clientEndpointToProviderEndpoint(createdEndpoint)
+	// A created endpoint that fails to start is still saved to state, so
+	// Terraform marks it tainted and replaces it on the next apply. An
+	// adopted endpoint may still report its previous revision as running,
+	// so it is waited on like any update.
+	var settledEndpoint EndpointDetails
+	if useUpdate {
+		settledEndpoint, err = r.waitForEndpointUpdateSettled(ctx, createdEndpoint, createTimeout)
+	} else {
+		settledEndpoint, err = r.waitForEndpointSettled(ctx, plan.Name.ValueString(), createTimeout)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating endpoint",
+			"endpoint "+plan.Name.ValueString()+" was created but did not become ready: "+err.Error(),
+		)
+	} else {
+		createdEndpoint = settledEndpoint
+		if createdEndpoint.Status.State == endpointStateFailed {
+			resp.Diagnostics.AddError(
+				"endpoint failed to start",
+				r.describeEndpointFailure(createdEndpoint),
+			)
+		}
+	}
+
+	createdPlan := clientEndpointToProviderEndpoint(createdEndpoint)
+	createdPlan.copyProviderOnlyAttributes(plan)
+	createdPlan.keepConfiguredCase(plan)
+	plan = createdPlan
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -854,7 +1444,7 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	endpoint, err := r.client.GetEndpoint(state.Name.ValueString())
+	endpoint, err := getEndpoint(r.client, state.Name.ValueString())
 	if err != nil {
 		httpErr, ok := err.(*huggingface.HTTPError)
 		if ok && httpErr.StatusCode == http.StatusNotFound {
@@ -869,7 +1459,10 @@ func (r *endpointResource) Read(ctx context.Context, req resource.ReadRequest, r
 		}
 	}
 
-	state = clientEndpointToProviderEndpoint(endpoint)
+	newState := clientEndpointToProviderEndpoint(endpoint)
+	newState.copyProviderOnlyAttributes(state)
+	newState.keepConfiguredCase(state)
+	state = newState
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -886,9 +1479,15 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultEndpointTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	endpoint := providerEndpointToUpdateEndpointRequest(plan)
 
-	updatedEndpoint, err := r.client.UpdateEndpoint(plan.Name.ValueString(), endpoint)
+	updatedEndpoint, err := r.updateEndpointWhenSettled(ctx, plan.Name.ValueString(), endpoint, updateTimeout)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"error updating endpoint",
@@ -897,7 +1496,73 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	plan = clientEndpointToProviderEndpoint(updatedEndpoint)
+	// The update is only known to have worked once the endpoint has been
+	// redeployed with it, which is when a bad image or setting shows.
+	settledEndpoint, err := r.waitForEndpointUpdateSettled(ctx, updatedEndpoint, updateTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating endpoint",
+			"endpoint "+plan.Name.ValueString()+" was updated but did not become ready: "+err.Error(),
+		)
+	} else if settledEndpoint.Status.State == endpointStateFailed {
+		updatedEndpoint = settledEndpoint
+		failure := r.describeEndpointFailure(settledEndpoint)
+
+		if !plan.RollbackOnFailure.ValueBool() {
+			resp.Diagnostics.AddError(
+				"endpoint update failed",
+				failure,
+			)
+		} else {
+			var state endpointResourceModel
+			diags = req.State.Get(ctx, &state)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			// The state is left as it was before the update, which is the
+			// configuration the endpoint is rolled back to.
+			rollback := providerEndpointToUpdateEndpointRequest(state)
+			rolledBackEndpoint, err := r.updateEndpointWhenSettled(ctx, plan.Name.ValueString(), rollback, updateTimeout)
+			if err != nil {
+				// The endpoint is left with the failed configuration.
+				resp.Diagnostics.AddError(
+					"error updating endpoint",
+					failure+"\n\nRolling back to the previous configuration failed too: "+err.Error(),
+				)
+			} else {
+				// The rollback is a deploy of its own, and is only done once
+				// the previous configuration is running again.
+				rolledBackEndpoint, err = r.waitForEndpointUpdateSettled(ctx, rolledBackEndpoint, updateTimeout)
+				switch {
+				case err != nil:
+					resp.Diagnostics.AddError(
+						"endpoint update failed and its rollback did not complete",
+						failure+"\n\nThe previous configuration was applied again but the endpoint did not become ready: "+err.Error(),
+					)
+				case rolledBackEndpoint.Status.State == endpointStateFailed:
+					resp.Diagnostics.AddError(
+						"endpoint update failed and its rollback failed too",
+						failure+"\n\nAfter applying the previous configuration again, "+r.describeEndpointFailure(rolledBackEndpoint),
+					)
+				default:
+					resp.Diagnostics.AddError(
+						"endpoint update failed and was rolled back",
+						failure,
+					)
+				}
+				return
+			}
+		}
+	} else {
+		updatedEndpoint = settledEndpoint
+	}
+
+	updatedPlan := clientEndpointToProviderEndpoint(updatedEndpoint)
+	updatedPlan.copyProviderOnlyAttributes(plan)
+	updatedPlan.keepConfiguredCase(plan)
+	plan = updatedPlan
 
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -906,6 +1571,131 @@ func (r *endpointResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 }
 
+// waitForEndpointSettled polls the endpoint until it leaves the states it is
+// busy applying a change in, and returns it as it was last seen.
+func (r *endpointResource) waitForEndpointSettled(ctx context.Context, name string, timeout time.Duration) (EndpointDetails, error) {
+	var endpoint EndpointDetails
+	err := waitFor(ctx, timeout, func() (bool, error) {
+		var err error
+		endpoint, err = getEndpoint(r.client, name)
+		if err != nil {
+			return false, err
+		}
+		return !endpointBusyStates[endpoint.Status.State], nil
+	})
+	return endpoint, err
+}
+
+// endpointUpdatePickupTimeout bounds how long an update may show no sign of
+// being deployed. Changes that don't need a redeploy may never show one.
+const endpointUpdatePickupTimeout = 2 * time.Minute
+
+// waitForEndpointUpdateSettled waits for an update to be deployed, given the
+// endpoint as returned when the update was accepted. At that point the
+// previous revision may still report running, so the endpoint only counts as
+// settled once the update has been picked up: it went through a busy state,
+// or its status.updatedAt moved past the one returned with the update.
+func (r *endpointResource) waitForEndpointUpdateSettled(ctx context.Context, updated EndpointDetails, timeout time.Duration) (EndpointDetails, error) {
+	pickupDeadline := time.Now().Add(endpointUpdatePickupTimeout)
+	pickedUp := endpointBusyStates[updated.Status.State]
+
+	endpoint := updated
+	err := waitFor(ctx, timeout, func() (bool, error) {
+		var err error
+		endpoint, err = getEndpoint(r.client, updated.Name)
+		if err != nil {
+			return false, err
+		}
+		if endpointBusyStates[endpoint.Status.State] {
+			pickedUp = true
+			return false, nil
+		}
+		if isLaterTimestamp(endpoint.Status.UpdatedAt, updated.Status.UpdatedAt) {
+			pickedUp = true
+		}
+		return pickedUp || time.Now().After(pickupDeadline), nil
+	})
+	return endpoint, err
+}
+
+// isLaterTimestamp reports whether the RFC 3339 timestamp a is after b. A
+// missing or malformed timestamp is never later, so the wait falls back to
+// the busy states.
+func isLaterTimestamp(a string, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339Nano, a)
+	timeB, errB := time.Parse(time.RFC3339Nano, b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return timeA.After(timeB)
+}
+
+// describeEndpointFailure explains why an endpoint failed, using its status
+// and the last lines of its logs, which usually hold the actual error. The
+// logs are best effort: when they can't be fetched or parsed, the status
+// alone is reported.
+func (r *endpointResource) describeEndpointFailure(endpoint EndpointDetails) string {
+	message := endpoint.Status.ErrorMessage
+	if message == "" {
+		message = endpoint.Status.Message
+	}
+	detail := "endpoint " + endpoint.Name + " failed: " + message
+
+	logs, err := getEndpointLogs(r.client, endpoint.Name, endpointLogLines)
+	if err == nil && len(logs) > 0 {
+		detail += fmt.Sprintf("\n\nLast %d log lines:\n%s", len(logs), strings.Join(logs, "\n"))
+	}
+	return detail
+}
+
+// updateEndpointWhenSettled sends an update, and when the endpoint is busy
+// with a previous change, waits for it to settle and tries again until
+// timeout elapses.
+func (r *endpointResource) updateEndpointWhenSettled(ctx context.Context, name string, request UpdateEndpointRequest, timeout time.Duration) (EndpointDetails, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		endpoint, err := updateEndpoint(r.client, name, request)
+		if err == nil || !isEndpointBusyError(err) {
+			return endpoint, err
+		}
+
+		// The endpoint may report a settled state slightly before it
+		// accepts changes again, so never retry right away.
+		select {
+		case <-ctx.Done():
+			return EndpointDetails{}, err
+		case <-time.After(pollInterval):
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return EndpointDetails{}, fmt.Errorf("endpoint %s was still busy after %s: %w", name, timeout, err)
+		}
+		_, waitErr := r.waitForEndpointSettled(ctx, name, remaining)
+		if waitErr != nil {
+			return EndpointDetails{}, fmt.Errorf("endpoint %s was busy and did not settle: %w", name, waitErr)
+		}
+	}
+}
+
+// waitForEndpointDeleted polls the endpoint until the API no longer knows it.
+func (r *endpointResource) waitForEndpointDeleted(ctx context.Context, name string, timeout time.Duration) error {
+	err := waitFor(ctx, timeout, func() (bool, error) {
+		_, err := getEndpoint(r.client, name)
+		if err != nil {
+			if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode == http.StatusNotFound {
+				return true, nil
+			}
+			return false, err
+		}
+		return false, nil
+	})
+	if err != nil {
+		return fmt.Errorf("endpoint %s was not deleted: %w", name, err)
+	}
+	return nil
+}
+
 func (r *endpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var state endpointResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -914,9 +1704,38 @@ func (r *endpointResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteEndpoint(state.Name.ValueString())
+	if state.DeletionProtection.ValueBool() && destroyDeletesEndpoint(state.OnDestroy) {
+		resp.Diagnostics.AddError(
+			"endpoint is protected from deletion",
+			"endpoint "+state.Name.ValueString()+" has deletion_protection enabled. Set deletion_protection to false and apply that change before destroying it.",
+		)
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultEndpointTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var err error
+	switch state.OnDestroy.ValueString() {
+	case onDestroyAbandon:
+		// The endpoint is left as it is and only forgotten by Terraform.
+	case onDestroyPause:
+		err = pauseEndpoint(r.client, state.Name.ValueString())
+	case onDestroyScaleToZero:
+		err = scaleEndpointToZero(r.client, state.Name.ValueString())
+	default:
+		err = r.client.DeleteEndpoint(state.Name.ValueString())
+		if err == nil {
+			// Deletion is asynchronous, and a replacement with the same name
+			// can't be created until the endpoint is really gone.
+			err = r.waitForEndpointDeleted(ctx, state.Name.ValueString(), deleteTimeout)
+		}
+	}
 	if err != nil {
-		if httpErr, ok := err.(*huggingface.HTTPError); ok && httpErr.StatusCode != http.StatusNotFound {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
 			resp.Diagnostics.AddError(
 				"error deleting endpoint",
 				err.Error(),
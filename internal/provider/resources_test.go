@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// endpointTestValue builds an endpoint object for the resource schema with
+// the given top-level attributes set and every other attribute null.
+func endpointTestValue(ctx context.Context, r *endpointResource, attributes map[string]tftypes.Value) tftypes.Value {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attributeType, nil)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func endpointCloudTestValue(ctx context.Context, r *endpointResource, vendor string, region string) tftypes.Value {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	cloudType := objectType.AttributeTypes["cloud"].(tftypes.Object)
+
+	return tftypes.NewValue(cloudType, map[string]tftypes.Value{
+		"vendor": tftypes.NewValue(tftypes.String, vendor),
+		"region": tftypes.NewValue(tftypes.String, region),
+	})
+}
+
+func TestEndpointModifyPlanDeletionProtection(t *testing.T) {
+	ctx := context.Background()
+	r := &endpointResource{}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	str := func(value string) tftypes.Value { return tftypes.NewValue(tftypes.String, value) }
+	null := tftypes.NewValue(tftypes.String, nil)
+	protected := tftypes.NewValue(tftypes.Bool, true)
+	unprotected := tftypes.NewValue(tftypes.Bool, false)
+
+	tests := map[string]struct {
+		state     map[string]tftypes.Value
+		plan      map[string]tftypes.Value
+		destroy   bool
+		wantError string
+	}{
+		"unprotected replace": {
+			state: map[string]tftypes.Value{"name": str("prod"), "deletion_protection": unprotected},
+			plan:  map[string]tftypes.Value{"name": str("prod-v2"), "deletion_protection": unprotected},
+		},
+		"protected replace by name": {
+			state:     map[string]tftypes.Value{"name": str("prod"), "deletion_protection": protected},
+			plan:      map[string]tftypes.Value{"name": str("prod-v2"), "deletion_protection": protected},
+			wantError: "changing name requires replacing it",
+		},
+		"protected replace by name_prefix": {
+			state:     map[string]tftypes.Value{"name": str("prod-1a2b3c4d"), "name_prefix": str("prod-"), "deletion_protection": protected},
+			plan:      map[string]tftypes.Value{"name": str("prod-1a2b3c4d"), "name_prefix": str("staging-"), "deletion_protection": protected},
+			wantError: "changing name_prefix requires replacing it",
+		},
+		"protected replace while disabling protection": {
+			state:     map[string]tftypes.Value{"name": str("prod"), "deletion_protection": protected},
+			plan:      map[string]tftypes.Value{"name": str("prod-v2"), "deletion_protection": unprotected},
+			wantError: "changing name requires replacing it",
+		},
+		"protected in-place update": {
+			state: map[string]tftypes.Value{"name": str("prod"), "type": str("public"), "deletion_protection": protected},
+			plan:  map[string]tftypes.Value{"name": str("prod"), "type": str("protected"), "deletion_protection": protected},
+		},
+		"protected region change": {
+			state: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "aws", "us-east-1"),
+				"deletion_protection": protected,
+			},
+			plan: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "aws", "eu-west-1"),
+				"deletion_protection": protected,
+			},
+			wantError: "changing cloud.region requires replacing it",
+		},
+		"protected vendor change": {
+			state: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "aws", "us-east-1"),
+				"deletion_protection": protected,
+			},
+			plan: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "azure", "eastus"),
+				"deletion_protection": protected,
+			},
+			wantError: "changing cloud.vendor, cloud.region requires replacing it",
+		},
+		"unprotected region change": {
+			state: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "aws", "us-east-1"),
+				"deletion_protection": unprotected,
+			},
+			plan: map[string]tftypes.Value{
+				"name":                str("prod"),
+				"cloud":               endpointCloudTestValue(ctx, r, "aws", "eu-west-1"),
+				"deletion_protection": unprotected,
+			},
+		},
+		"protected destroy": {
+			state:     map[string]tftypes.Value{"name": str("prod"), "name_prefix": null, "deletion_protection": protected},
+			destroy:   true,
+			wantError: "Set deletion_protection to false and apply that change before destroying it",
+		},
+		"protected destroy with on_destroy delete": {
+			state:     map[string]tftypes.Value{"name": str("prod"), "on_destroy": str("delete"), "deletion_protection": protected},
+			destroy:   true,
+			wantError: "Set deletion_protection to false and apply that change before destroying it",
+		},
+		"protected destroy with on_destroy pause": {
+			state:   map[string]tftypes.Value{"name": str("prod"), "on_destroy": str("pause"), "deletion_protection": protected},
+			destroy: true,
+		},
+		"protected destroy with on_destroy abandon": {
+			state:   map[string]tftypes.Value{"name": str("prod"), "on_destroy": str("abandon"), "deletion_protection": protected},
+			destroy: true,
+		},
+		"protected replace with on_destroy scale_to_zero": {
+			state: map[string]tftypes.Value{"name": str("prod"), "on_destroy": str("scale_to_zero"), "deletion_protection": protected},
+			plan:  map[string]tftypes.Value{"name": str("prod-v2"), "on_destroy": str("scale_to_zero"), "deletion_protection": protected},
+		},
+		"protected replace switching on_destroy to pause": {
+			state:     map[string]tftypes.Value{"name": str("prod"), "on_destroy": str("delete"), "deletion_protection": protected},
+			plan:      map[string]tftypes.Value{"name": str("prod-v2"), "on_destroy": str("pause"), "deletion_protection": protected},
+			wantError: "changing name requires replacing it",
+		},
+		"unprotected destroy": {
+			state:   map[string]tftypes.Value{"name": str("prod"), "deletion_protection": unprotected},
+			destroy: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			plan := tfsdk.Plan{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			}
+			if !test.destroy {
+				plan.Raw = endpointTestValue(ctx, r, test.plan)
+			}
+			req := resource.ModifyPlanRequest{
+				State: tfsdk.State{Schema: schemaResp.Schema, Raw: endpointTestValue(ctx, r, test.state)},
+				Plan:  plan,
+			}
+			resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+
+			r.ModifyPlan(ctx, req, resp)
+
+			if test.wantError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected an error containing %q, got none", test.wantError)
+			}
+			detail := resp.Diagnostics.Errors()[0].Detail()
+			if !strings.Contains(detail, test.wantError) {
+				t.Errorf("error %q doesn't contain %q", detail, test.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,524 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &spaceResource{}
+	_ resource.ResourceWithConfigure   = &spaceResource{}
+	_ resource.ResourceWithImportState = &spaceResource{}
+)
+
+const defaultSpaceTimeout = 20 * time.Minute
+
+// spaceErrorStages are the runtime stages a Space does not leave on its own.
+var spaceErrorStages = map[string]bool{
+	"BUILD_ERROR":   true,
+	"RUNTIME_ERROR": true,
+	"CONFIG_ERROR":  true,
+	"NO_APP_FILE":   true,
+}
+
+func NewSpaceResource() resource.Resource {
+	return &spaceResource{}
+}
+
+type spaceResource struct {
+	hub *hubClient
+}
+
+type spaceResourceModel struct {
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Namespace      types.String   `tfsdk:"namespace"`
+	Sdk            types.String   `tfsdk:"sdk"`
+	Hardware       types.String   `tfsdk:"hardware"`
+	SleepTime      types.Int64    `tfsdk:"sleep_time"`
+	Storage        types.String   `tfsdk:"storage"`
+	Private        types.Bool     `tfsdk:"private"`
+	Template       types.String   `tfsdk:"template"`
+	WaitForRunning types.Bool     `tfsdk:"wait_for_running"`
+	Stage          types.String   `tfsdk:"stage"`
+	Host           types.String   `tfsdk:"host"`
+	URL            types.String   `tfsdk:"url"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *spaceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *spaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space"
+}
+
+func (r *spaceResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Space, optionally duplicated from a template Space.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The Space ID (\"namespace/name\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The Space name. Changing it replaces the Space.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "The owning user or organization. Defaults to the provider `namespace`. Changing it replaces the Space.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"sdk": schema.StringAttribute{
+				MarkdownDescription: "The Space SDK: \"gradio\", \"streamlit\", \"docker\" or \"static\". Required unless `template` is set. Changing it replaces the Space.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("gradio", "streamlit", "docker", "static"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"hardware": schema.StringAttribute{
+				MarkdownDescription: "The requested hardware flavor, e.g. \"cpu-basic\" or \"t4-small\".",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sleep_time": schema.Int64Attribute{
+				MarkdownDescription: "Seconds of inactivity before the Space goes to sleep.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"storage": schema.StringAttribute{
+				MarkdownDescription: "The persistent storage tier: \"small\", \"medium\" or \"large\". Removing it deletes the stored data.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("small", "medium", "large"),
+				},
+			},
+			"private": schema.BoolAttribute{
+				MarkdownDescription: "Whether the Space is private. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"template": schema.StringAttribute{
+				MarkdownDescription: "The ID of a Space to duplicate, including its files and SDK. Changing it replaces the Space.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_running": schema.BoolAttribute{
+				MarkdownDescription: "Whether creating and updating the Space wait for it to reach the RUNNING stage, up to `timeouts.create` and `timeouts.update` (20 minutes by default). Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"stage": schema.StringAttribute{
+				MarkdownDescription: "The runtime stage, e.g. \"RUNNING\", \"BUILDING\" or \"SLEEPING\".",
+				Computed:            true,
+			},
+			"host": schema.StringAttribute{
+				MarkdownDescription: "The URL the Space app is served at.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The Space page on the Hub.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timeouts": timeouts.Attributes(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *spaceResource) hubSpaceToSpaceResource(space HubSpace, prior spaceResourceModel) (spaceResourceModel, error) {
+	namespace, name, err := splitRepoID(space.ID)
+	if err != nil {
+		return spaceResourceModel{}, err
+	}
+
+	hardware := space.Runtime.Hardware.Requested
+	if hardware == nil {
+		hardware = space.Runtime.Hardware.Current
+	}
+
+	host := types.StringNull()
+	if space.Host != "" {
+		host = types.StringValue(space.Host)
+	}
+
+	return spaceResourceModel{
+		ID:             types.StringValue(space.ID),
+		Name:           types.StringValue(name),
+		Namespace:      types.StringValue(namespace),
+		Sdk:            types.StringValue(space.Sdk),
+		Hardware:       types.StringPointerValue(hardware),
+		SleepTime:      types.Int64PointerValue(space.Runtime.GcTimeout),
+		Storage:        types.StringPointerValue(space.Runtime.Storage),
+		Private:        types.BoolValue(space.Private),
+		Template:       prior.Template,
+		WaitForRunning: prior.WaitForRunning,
+		Stage:          types.StringValue(space.Runtime.Stage),
+		Host:           host,
+		URL:            types.StringValue(r.hub.repoURL("space", space.ID)),
+		Timeouts:       prior.Timeouts,
+	}, nil
+}
+
+// waitForSpaceRunning polls the Space runtime until it reaches RUNNING, and
+// fails early if it lands in a stage it cannot recover from on its own.
+func (r *spaceResource) waitForSpaceRunning(ctx context.Context, repoID string, timeout time.Duration) error {
+	return waitFor(ctx, timeout, func() (bool, error) {
+		runtime, err := r.hub.GetSpaceRuntime(repoID)
+		if err != nil {
+			return false, err
+		}
+		if spaceErrorStages[runtime.Stage] {
+			return false, fmt.Errorf("space %s is in stage %s: %s", repoID, runtime.Stage, runtime.ErrorMessage)
+		}
+		return runtime.Stage == "RUNNING", nil
+	})
+}
+
+func (r *spaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace := plan.Namespace.ValueString()
+	if plan.Namespace.IsUnknown() || plan.Namespace.IsNull() || namespace == "" {
+		namespace = r.hub.Namespace
+	}
+	repoID := namespace + "/" + plan.Name.ValueString()
+
+	var err error
+	if !plan.Template.IsNull() {
+		err = r.hub.DuplicateSpace(plan.Template.ValueString(), HubDuplicateSpaceRequest{
+			Repository:       repoID,
+			Private:          plan.Private.ValueBool(),
+			Hardware:         plan.Hardware.ValueString(),
+			StorageTier:      plan.Storage.ValueString(),
+			SleepTimeSeconds: plan.SleepTime.ValueInt64Pointer(),
+		})
+	} else {
+		if plan.Sdk.IsUnknown() || plan.Sdk.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("sdk"),
+				"missing space sdk",
+				"sdk must be set unless the space is duplicated from a template",
+			)
+			return
+		}
+		_, err = r.hub.CreateRepo(HubCreateRepoRequest{
+			Name:             plan.Name.ValueString(),
+			Organization:     namespace,
+			Type:             "space",
+			Private:          plan.Private.ValueBool(),
+			Sdk:              plan.Sdk.ValueString(),
+			Hardware:         plan.Hardware.ValueString(),
+			StorageTier:      plan.Storage.ValueString(),
+			SleepTimeSeconds: plan.SleepTime.ValueInt64Pointer(),
+		})
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating space",
+			err.Error(),
+		)
+		return
+	}
+
+	if plan.WaitForRunning.ValueBool() {
+		createTimeout, diags := plan.Timeouts.Create(ctx, defaultSpaceTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err = r.waitForSpaceRunning(ctx, repoID, createTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error waiting for space to run",
+				err.Error(),
+			)
+		}
+	}
+
+	// The space exists from here on, so it is kept in state even when it
+	// didn't start or can't be read back, to be fixed or destroyed.
+	state := createdSpaceState(plan, repoID, namespace)
+	space, err := r.hub.GetSpace(repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading space",
+			"could not read space "+repoID+": "+err.Error(),
+		)
+	} else if read, err := r.hubSpaceToSpaceResource(space, plan); err != nil {
+		resp.Diagnostics.AddError("error reading space", err.Error())
+	} else {
+		state = read
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// createdSpaceState is the state of a space that was created but couldn't
+// be read back: the plan, with the values only the Hub knows left null.
+func createdSpaceState(plan spaceResourceModel, repoID string, namespace string) spaceResourceModel {
+	state := plan
+	state.ID = types.StringValue(repoID)
+	state.Namespace = types.StringValue(namespace)
+	if state.Sdk.IsUnknown() {
+		state.Sdk = types.StringNull()
+	}
+	if state.Hardware.IsUnknown() {
+		state.Hardware = types.StringNull()
+	}
+	if state.SleepTime.IsUnknown() {
+		state.SleepTime = types.Int64Null()
+	}
+	if state.Private.IsUnknown() {
+		state.Private = types.BoolNull()
+	}
+	if state.WaitForRunning.IsUnknown() {
+		state.WaitForRunning = types.BoolNull()
+	}
+	if state.Stage.IsUnknown() {
+		state.Stage = types.StringNull()
+	}
+	if state.Host.IsUnknown() {
+		state.Host = types.StringNull()
+	}
+	if state.URL.IsUnknown() {
+		state.URL = types.StringNull()
+	}
+	return state
+}
+
+func (r *spaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	space, err := r.hub.GetSpace(state.ID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading space",
+				"could not read space "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	newState, err := r.hubSpaceToSpaceResource(space, state)
+	if err != nil {
+		resp.Diagnostics.AddError("error reading space", err.Error())
+		return
+	}
+	if newState.WaitForRunning.IsNull() {
+		newState.WaitForRunning = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state spaceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repoID := state.ID.ValueString()
+
+	if !plan.Private.Equal(state.Private) {
+		err := r.hub.UpdateRepoSettings("space", repoID, HubRepoSettings{
+			Private: plan.Private.ValueBoolPointer(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("error updating space visibility", err.Error())
+			return
+		}
+	}
+
+	hardwareChanged := !plan.Hardware.IsUnknown() && !plan.Hardware.Equal(state.Hardware)
+	sleepTimeChanged := !plan.SleepTime.IsUnknown() && !plan.SleepTime.Equal(state.SleepTime)
+
+	if hardwareChanged {
+		err := r.hub.RequestSpaceHardware(repoID, HubSpaceHardwareRequest{
+			Flavor:           plan.Hardware.ValueString(),
+			SleepTimeSeconds: plan.SleepTime.ValueInt64Pointer(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("error requesting space hardware", err.Error())
+			return
+		}
+	} else if sleepTimeChanged {
+		err := r.hub.SetSpaceSleepTime(repoID, plan.SleepTime.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError("error setting space sleep time", err.Error())
+			return
+		}
+	}
+
+	if !plan.Storage.Equal(state.Storage) {
+		var err error
+		if plan.Storage.IsNull() {
+			err = r.hub.DeleteSpaceStorage(repoID)
+		} else {
+			err = r.hub.RequestSpaceStorage(repoID, plan.Storage.ValueString())
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("error updating space storage", err.Error())
+			return
+		}
+	}
+
+	if plan.WaitForRunning.ValueBool() {
+		updateTimeout, diags := plan.Timeouts.Update(ctx, defaultSpaceTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		err := r.waitForSpaceRunning(ctx, repoID, updateTimeout)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error waiting for space to run",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	space, err := r.hub.GetSpace(repoID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error reading space",
+			"could not read space "+repoID+": "+err.Error(),
+		)
+		return
+	}
+
+	newState, err := r.hubSpaceToSpaceResource(space, plan)
+	if err != nil {
+		resp.Diagnostics.AddError("error reading space", err.Error())
+		return
+	}
+
+	diags := resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace, name, err := splitRepoID(state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("error deleting space", err.Error())
+		return
+	}
+
+	err = r.hub.DeleteRepo(HubDeleteRepoRequest{
+		Name:         name,
+		Organization: namespace,
+		Type:         "space",
+	})
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting space",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *spaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
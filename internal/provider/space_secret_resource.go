@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource               = &spaceSecretResource{}
+	_ resource.ResourceWithConfigure  = &spaceSecretResource{}
+	_ resource.ResourceWithModifyPlan = &spaceSecretResource{}
+)
+
+func NewSpaceSecretResource() resource.Resource {
+	return &spaceSecretResource{}
+}
+
+type spaceSecretResource struct {
+	hub *hubClient
+}
+
+type spaceSecretResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	SpaceID        types.String `tfsdk:"space_id"`
+	Key            types.String `tfsdk:"key"`
+	Value          types.String `tfsdk:"value"`
+	ValueWoVersion types.Int64  `tfsdk:"value_wo_version"`
+	ValueHash      types.String `tfsdk:"value_hash"`
+	Description    types.String `tfsdk:"description"`
+}
+
+func (r *spaceSecretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *spaceSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_space_secret"
+}
+
+func (r *spaceSecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a secret of a Space, for example the token a demo uses to call an endpoint. The value is write-only, which requires Terraform 1.11 or later. Secrets can't be imported since their value can't be read back.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The secret ID (\"namespace/name/KEY\").",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"space_id": schema.StringAttribute{
+				MarkdownDescription: "The Space ID (\"namespace/name\"). Changing it replaces the secret.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The secret name. Changing it replaces the secret.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The secret value. It is write-only: it is sent to the Hub but never stored in the plan or state, and only its SHA-256 is kept in `value_hash`.",
+				Required:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"value_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Changing it writes `value` to the Space again, for example after the secret was changed in the Space settings, which Terraform can't detect.",
+				Optional:            true,
+			},
+			"value_hash": schema.StringAttribute{
+				MarkdownDescription: "The hex-encoded SHA-256 of `value`. A new `value` changes it, which updates the secret.",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "A description shown in the Space settings.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// spaceKeyID builds the id of a Space secret or variable, "namespace/name/KEY".
+func spaceKeyID(spaceID string, key string) string {
+	return spaceID + "/" + key
+}
+
+// splitSpaceKeyID splits a "namespace/name/KEY" id into the Space id and key.
+func splitSpaceKeyID(id string) (string, string, error) {
+	i := strings.LastIndex(id, "/")
+	if i < 0 {
+		return "", "", fmt.Errorf("expected an id of the form namespace/name/KEY, got %q", id)
+	}
+	if _, _, err := splitRepoID(id[:i]); err != nil || id[i+1:] == "" {
+		return "", "", fmt.Errorf("expected an id of the form namespace/name/KEY, got %q", id)
+	}
+	return id[:i], id[i+1:], nil
+}
+
+// hashSecretValue returns the hex encoded sha256 of a secret. The value is
+// write-only, so the hash is the only way to tell from state which value was
+// last written without exposing it.
+func hashSecretValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// ModifyPlan plans value_hash from the configured value. The value itself
+// never reaches the plan, so the hash is what makes a new value a change.
+func (r *spaceSecretResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var value types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("value"), &value)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	valueHash := types.StringUnknown()
+	if !value.IsUnknown() {
+		valueHash = types.StringValue(hashSecretValue(value.ValueString()))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("value_hash"), valueHash)...)
+}
+
+// putSecret writes value, which being write-only is read from the
+// configuration rather than the plan.
+func (r *spaceSecretResource) putSecret(plan spaceSecretResourceModel, value string) error {
+	return r.hub.AddSpaceSecret(plan.SpaceID.ValueString(), HubSpaceKeyValue{
+		Key:         plan.Key.ValueString(),
+		Value:       value,
+		Description: plan.Description.ValueStringPointer(),
+	})
+}
+
+func (r *spaceSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan spaceSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var value types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("value"), &value)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.putSecret(plan, value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating space secret",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(spaceKeyID(plan.SpaceID.ValueString(), plan.Key.ValueString()))
+	plan.ValueHash = types.StringValue(hashSecretValue(value.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state spaceSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Secret values can't be read back, so the best that can be checked is
+	// that the Space still exists.
+	_, err := r.hub.GetRepo("space", state.SpaceID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading space secret",
+				"could not read space "+state.SpaceID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan spaceSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var value types.String
+	diags = req.Config.GetAttribute(ctx, path.Root("value"), &value)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.putSecret(plan, value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating space secret",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.ValueHash = types.StringValue(hashSecretValue(value.ValueString()))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *spaceSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state spaceSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteSpaceSecret(state.SpaceID.ValueString(), state.Key.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting space secret",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
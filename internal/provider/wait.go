@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pollInterval is how often long-running operations are checked on.
+const pollInterval = 10 * time.Second
+
+// waitFor calls check every pollInterval until it reports done, returns an
+// error, or timeout elapses. The first check runs immediately.
+func waitFor(ctx context.Context, timeout time.Duration, check func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/issamemari/huggingface-endpoints-client-go"
+)
+
+var (
+	_ resource.Resource                = &webhookResource{}
+	_ resource.ResourceWithConfigure   = &webhookResource{}
+	_ resource.ResourceWithImportState = &webhookResource{}
+)
+
+func NewWebhookResource() resource.Resource {
+	return &webhookResource{}
+}
+
+type webhookResource struct {
+	hub *hubClient
+}
+
+type webhookResourceModel struct {
+	ID       types.String     `tfsdk:"id"`
+	URL      types.String     `tfsdk:"url"`
+	Watched  []WebhookWatched `tfsdk:"watched"`
+	Domains  []string         `tfsdk:"domains"`
+	Secret   types.String     `tfsdk:"secret"`
+	Disabled types.Bool       `tfsdk:"disabled"`
+}
+
+type WebhookWatched struct {
+	Type string `tfsdk:"type"`
+	Name string `tfsdk:"name"`
+}
+
+func (r *webhookResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	data, ok := req.ProviderData.(*providerData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"unexpected resource configure type",
+			fmt.Sprintf("expected *providerData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+	r.hub = data.hub
+}
+
+func (r *webhookResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *webhookResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	defaultDomains := make([]attr.Value, 0, len(webhookDomains))
+	for _, domain := range webhookDomains {
+		defaultDomains = append(defaultDomains, types.StringValue(domain))
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Sends Hub events for repositories, users or organizations to a URL, for example to trigger a redeploy when new weights are pushed. Changes made in the Hub settings, including to the secret, show up as drift.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The webhook ID.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				MarkdownDescription: "The URL events are posted to.",
+				Required:            true,
+			},
+			"watched": schema.ListNestedAttribute{
+				MarkdownDescription: "What to watch.",
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The kind of thing watched: \"user\", \"org\", \"model\", \"dataset\" or \"space\".",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(webhookWatchedTypes...),
+							},
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The user or organization name, or the repository ID.",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"domains": schema.ListAttribute{
+				MarkdownDescription: "Which events to send: \"repo\" (commits, tags, settings) and \"discussion\". Defaults to both.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, defaultDomains)),
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(webhookDomains...)),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Sent in the `X-Webhook-Secret` header so the receiver can authenticate calls.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"disabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether delivery is paused. Defaults to false.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func webhookRequest(plan webhookResourceModel) HubWebhookRequest {
+	request := HubWebhookRequest{
+		URL:     plan.URL.ValueString(),
+		Domains: plan.Domains,
+	}
+	for _, watched := range plan.Watched {
+		request.Watched = append(request.Watched, HubWebhookWatched{Type: watched.Type, Name: watched.Name})
+	}
+	// An empty secret removes it.
+	secret := plan.Secret.ValueString()
+	request.Secret = &secret
+	return request
+}
+
+func hubWebhookToWebhookResource(webhook HubWebhook, prior webhookResourceModel) webhookResourceModel {
+	state := webhookResourceModel{
+		ID:       types.StringValue(webhook.ID),
+		URL:      types.StringValue(webhook.URL),
+		Domains:  webhook.Domains,
+		Disabled: types.BoolValue(webhook.Disabled),
+	}
+	if state.Domains == nil {
+		state.Domains = []string{}
+	}
+	state.Watched = make([]WebhookWatched, 0, len(webhook.Watched))
+	for _, watched := range webhook.Watched {
+		state.Watched = append(state.Watched, WebhookWatched{Type: watched.Type, Name: watched.Name})
+	}
+
+	// A missing secret is reported as null or an empty string.
+	if webhook.Secret != nil && *webhook.Secret != "" {
+		state.Secret = types.StringPointerValue(webhook.Secret)
+	} else if !prior.Secret.IsNull() {
+		state.Secret = types.StringValue("")
+	}
+
+	return state
+}
+
+func (r *webhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan webhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	request := webhookRequest(plan)
+	if plan.Secret.IsNull() {
+		request.Secret = nil
+	}
+	webhook, err := r.hub.CreateWebhook(request)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error creating webhook",
+			err.Error(),
+		)
+		return
+	}
+
+	if plan.Disabled.ValueBool() {
+		err = r.hub.SetWebhookDisabled(webhook.ID, true)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error disabling webhook",
+				"webhook "+webhook.ID+" was created but could not be disabled: "+err.Error(),
+			)
+			return
+		}
+		webhook.Disabled = true
+	}
+
+	state := hubWebhookToWebhookResource(webhook, plan)
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *webhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state webhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.hub.GetWebhook(state.ID.ValueString())
+	if err != nil {
+		httpErr, ok := err.(*huggingface.HTTPError)
+		if ok && httpErr.StatusCode == http.StatusNotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		} else {
+			resp.Diagnostics.AddError(
+				"error reading webhook",
+				"could not read webhook "+state.ID.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	newState := hubWebhookToWebhookResource(webhook, state)
+
+	diags = resp.State.Set(ctx, &newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *webhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state webhookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	webhook, err := r.hub.UpdateWebhook(id, webhookRequest(plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"error updating webhook",
+			err.Error(),
+		)
+		return
+	}
+
+	if plan.Disabled.ValueBool() != webhook.Disabled {
+		err = r.hub.SetWebhookDisabled(id, plan.Disabled.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"error updating webhook",
+				err.Error(),
+			)
+			return
+		}
+		webhook.Disabled = plan.Disabled.ValueBool()
+	}
+
+	newState := hubWebhookToWebhookResource(webhook, plan)
+
+	diags = resp.State.Set(ctx, newState)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+func (r *webhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state webhookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.hub.DeleteWebhook(state.ID.ValueString())
+	if err != nil {
+		if httpErr, ok := err.(*huggingface.HTTPError); !ok || httpErr.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError(
+				"error deleting webhook",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r *webhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}